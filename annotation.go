@@ -0,0 +1,357 @@
+package biosigio
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AnnotationsLabel is the label of an EDF+ signal holding Time-stamped
+// Annotation Lists (TALs) instead of samples
+const AnnotationsLabel = "EDF Annotations"
+
+// BDFAnnotationsLabel is the label of a BDF+ annotation signal, whose TALs
+// fill 3-byte samples
+const BDFAnnotationsLabel = "BDF Annotations"
+
+const (
+	talDuration  = '\x15'
+	talSeparator = '\x14'
+	talEnd       = '\x00'
+)
+
+// Annotation is one event of an EDF+ annotation signal. Onset is measured
+// from the start of the recording. A zero Duration means none was given.
+type Annotation struct {
+	Onset    time.Duration
+	Duration time.Duration
+	Text     string
+}
+
+// tal holds one Time-stamped Annotation List
+type tal struct {
+	onset    time.Duration
+	duration time.Duration
+	texts    []string
+}
+
+// parseTALs decodes the TALs in the bytes of one annotation signal of one
+// data record, skipping the trailing NUL padding
+func parseTALs(buf []byte) (tals []tal, err error) {
+	for len(buf) > 0 {
+		if buf[0] == talEnd {
+			buf = buf[1:]
+			continue
+		}
+		end := bytes.IndexByte(buf, talEnd)
+		if end < 0 {
+			return nil, fmt.Errorf("TAL %q is not terminated", buf)
+		}
+		t, err := parseTAL(buf[:end])
+		if err != nil {
+			return nil, err
+		}
+		tals = append(tals, t)
+		buf = buf[end+1:]
+	}
+	return tals, nil
+}
+
+// parseTAL decodes +onset[\x15duration]\x14[text\x14]...
+func parseTAL(buf []byte) (t tal, err error) {
+	fields := strings.Split(string(buf), string(talSeparator))
+	if len(fields) < 2 || fields[len(fields)-1] != "" {
+		return t, fmt.Errorf("TAL %q does not end with a separator", buf)
+	}
+	stamp := fields[0]
+	if len(stamp) == 0 || (stamp[0] != '+' && stamp[0] != '-') {
+		return t, fmt.Errorf("TAL onset %q must start with + or -", stamp)
+	}
+	onset, duration := stamp, ""
+	if idx := strings.IndexByte(stamp, talDuration); idx >= 0 {
+		onset, duration = stamp[:idx], stamp[idx+1:]
+	}
+	if t.onset, err = asciiToDuration([]byte(onset)); err != nil {
+		return t, fmt.Errorf("TAL onset: %v", err)
+	}
+	if duration != "" {
+		if t.duration, err = asciiToDuration([]byte(duration)); err != nil || t.duration < 0 {
+			return t, fmt.Errorf("TAL duration %q is invalid", duration)
+		}
+	}
+	t.texts = fields[1 : len(fields)-1]
+	return t, nil
+}
+
+// isAnnotationLabel reports whether label marks an EDF+ or BDF+ annotation
+// signal
+func isAnnotationLabel(label string) bool {
+	return label == AnnotationsLabel || label == BDFAnnotationsLabel
+}
+
+// AnnotationSignals returns the indices of the EDF+ and BDF+ annotation
+// signals
+func (h *Header) AnnotationSignals() (idxs []int) {
+	for idx, label := range h.label {
+		if isAnnotationLabel(trimField(label[:])) {
+			idxs = append(idxs, idx)
+		}
+	}
+	return idxs
+}
+
+// signalBytes returns the raw bytes of signal idx
+func (d *Record[T]) signalBytes(idx int) []byte {
+	return appendSamples(nil, d.Signals[idx])
+}
+
+// RecordAnnotations decodes the annotations of data record idx. The start of
+// the data record is taken from the time-keeping annotation of the first
+// annotation signal and is not included in anns.
+func (f *File[T]) RecordAnnotations(idx int) (start time.Duration, anns []Annotation, err error) {
+	if idx < 0 || idx >= len(f.DataRecords) {
+		return 0, nil, fmt.Errorf("data record %v out of range [0, %v)", idx, len(f.DataRecords))
+	}
+	sigs := f.Header.AnnotationSignals()
+	if len(sigs) == 0 {
+		return 0, nil, fmt.Errorf("no %q or %q signal", AnnotationsLabel, BDFAnnotationsLabel)
+	}
+	for ids, sig := range sigs {
+		tals, err := parseTALs(f.DataRecords[idx].signalBytes(sig))
+		if err != nil {
+			return 0, nil, fmt.Errorf("data record %v: %v", idx, err)
+		}
+		for idt, t := range tals {
+			texts := t.texts
+			if ids == 0 && idt == 0 {
+				if len(texts) == 0 || texts[0] != "" {
+					return 0, nil, fmt.Errorf("data record %v: missing time-keeping annotation", idx)
+				}
+				start = t.onset
+				texts = texts[1:]
+			}
+			for _, text := range texts {
+				anns = append(anns, Annotation{Onset: t.onset, Duration: t.duration, Text: text})
+			}
+		}
+	}
+	return start, anns, nil
+}
+
+// RecordStart returns the start of data record idx relative to the start of
+// the recording. Without an annotation signal the data records are taken to
+// be contiguous.
+func (f *File[T]) RecordStart(idx int) (time.Duration, error) {
+	if len(f.Header.AnnotationSignals()) == 0 {
+		duration, err := asciiToDuration(f.Header.duration[:])
+		if err != nil {
+			return 0, fmt.Errorf("serialize ascii to duration: %v, for %v", err, f.Header.duration)
+		}
+		return time.Duration(idx) * duration, nil
+	}
+	start, _, err := f.RecordAnnotations(idx)
+	return start, err
+}
+
+// Annotations decodes the annotations of all data records in order
+func (f *File[T]) Annotations() (anns []Annotation, err error) {
+	if len(f.Header.AnnotationSignals()) == 0 {
+		return nil, nil
+	}
+	for idx := range f.DataRecords {
+		_, recordAnns, err := f.RecordAnnotations(idx)
+		if err != nil {
+			return nil, err
+		}
+		anns = append(anns, recordAnns...)
+	}
+	return anns, nil
+}
+
+// appendTAL encodes one TAL
+func appendTAL(buf []byte, onset, duration time.Duration, texts []string) []byte {
+	buf = append(buf, durationToASCII(onset)...)
+	if duration != 0 {
+		buf = append(buf, talDuration)
+		buf = append(buf, durationToASCII(duration)[1:]...)
+	}
+	buf = append(buf, talSeparator)
+	for _, text := range texts {
+		buf = append(buf, text...)
+		buf = append(buf, talSeparator)
+	}
+	return append(buf, talEnd)
+}
+
+// annotationSignal returns the header fields of an annotation signal of
+// numsample samples of byteSize bytes, labelled and ranged for EDF+ or BDF+
+func annotationSignal(numsample int, byteSize int) (sig signalHeader, err error) {
+	label, digmin, digmax := AnnotationsLabel, edfSampleMin, edfSampleMax
+	if byteSize == BDFDataByteSize {
+		label, digmin, digmax = BDFAnnotationsLabel, bdfSampleMin, bdfSampleMax
+	}
+	for _, field := range []struct {
+		dst []byte
+		val string
+	}{
+		{sig.label[:], label},
+		{sig.transducerType[:], ""},
+		{sig.phydim[:], ""},
+		{sig.phymin[:], "-1"},
+		{sig.phymax[:], "1"},
+		{sig.digmin[:], strconv.Itoa(digmin)},
+		{sig.digmax[:], strconv.Itoa(digmax)},
+		{sig.prefilter[:], ""},
+		{sig.numsample[:], strconv.Itoa(numsample)},
+		{sig.nsreserved[:], ""},
+	} {
+		if err = setField(field.dst, field.val); err != nil {
+			return sig, err
+		}
+	}
+	return sig, nil
+}
+
+// writeAnnotations replaces the annotation signals with a single signal
+// holding the time-keeping annotation starts[i] for data record i, followed
+// by each annotation in the data record its onset falls into. The reserved
+// field is set to EDF+ or BDF+ by sample size, staying discontinuous if it was.
+// A plain EDF or BDF header also gets EDF+ patient and recording
+// identifications, unknown subfields written as X, unless they already parse.
+func (f *File[T]) writeAnnotations(starts []time.Duration, anns []Annotation) error {
+	if len(f.DataRecords) == 0 {
+		return fmt.Errorf("no data records to hold annotations")
+	}
+	if len(starts) != len(f.DataRecords) {
+		return fmt.Errorf("number of data records [%v] must equal number of start times [%v]",
+			len(f.DataRecords), len(starts))
+	}
+	sorted := make([]Annotation, len(anns))
+	copy(sorted, anns)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Onset < sorted[j].Onset })
+
+	tals := make([][]byte, len(f.DataRecords))
+	for idx, start := range starts {
+		tals[idx] = appendTAL(nil, start, 0, []string{""})
+	}
+	for _, ann := range sorted {
+		if strings.ContainsAny(ann.Text, "\x00\x14\x15") {
+			return fmt.Errorf("annotation text %q contains a TAL delimiter", ann.Text)
+		}
+		if ann.Duration < 0 {
+			return fmt.Errorf("annotation %q has negative duration %v", ann.Text, ann.Duration)
+		}
+		idx := sort.Search(len(starts), func(i int) bool { return starts[i] > ann.Onset }) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		tals[idx] = appendTAL(tals[idx], ann.Onset, ann.Duration, []string{ann.Text})
+	}
+	byteSize := sampleSize[T]()
+	var numsample int
+	for _, buf := range tals {
+		if n := (len(buf) + byteSize - 1) / byteSize; n > numsample {
+			numsample = n
+		}
+	}
+
+	annSig, err := annotationSignal(numsample, byteSize)
+	if err != nil {
+		return err
+	}
+	isAnn := make(map[int]bool)
+	for _, idx := range f.Header.AnnotationSignals() {
+		isAnn[idx] = true
+	}
+	// kept maps each new signal to its old index, -1 marking the annotations
+	var kept []int
+	placed := false
+	for idx := range f.Header.label {
+		if !isAnn[idx] {
+			kept = append(kept, idx)
+		} else if !placed {
+			kept = append(kept, -1)
+			placed = true
+		}
+	}
+	if !placed {
+		kept = append(kept, -1)
+	}
+
+	old := f.Header.signalHeaders()
+	sigs := make([]signalHeader, len(kept))
+	for idx, val := range kept {
+		if val < 0 {
+			sigs[idx] = annSig
+		} else {
+			sigs[idx] = old[val]
+		}
+	}
+	h, err := f.Header.withSignals(sigs)
+	if err != nil {
+		return err
+	}
+	if err = h.setReserved(plusReserved(byteSize, h.Discontinuous())); err != nil {
+		return err
+	}
+	if !f.Header.EDFPlus() {
+		if err = h.plusIdentification(); err != nil {
+			return err
+		}
+	}
+	for idr, record := range f.DataRecords {
+		signals := make([][]T, len(kept))
+		for idx, val := range kept {
+			if val >= 0 {
+				signals[idx] = record.Signals[val]
+				continue
+			}
+			buf := make([]byte, numsample*byteSize)
+			copy(buf, tals[idr])
+			if signals[idx], err = toSamples[T](buf); err != nil {
+				return err
+			}
+		}
+		record.Signals = signals
+		record.rawData = nil
+	}
+	f.Header = h
+	return nil
+}
+
+// plusIdentification replaces patient and recording identifications that do
+// not parse as EDF+ with ones holding unknown subfields, keeping the startdate
+func (h *Header) plusIdentification() error {
+	if _, err := ParsePatientID(trimField(h.LPID[:])); err != nil {
+		if err = h.setLPID(PatientID{}.String()); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseRecordingID(trimField(h.LRID[:])); err != nil {
+		var r RecordingID
+		if date, err := h.startDate(); err == nil {
+			r.Startdate = date
+		}
+		if err = h.setLRID(r.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetAnnotations stores anns in the EDF+ or BDF+ annotation signal, replacing
+// any existing annotations, so that MarshalFile writes them with the data
+// records. The signal is added to the header if missing, with the
+// time-keeping annotations taken from RecordStart.
+func (f *File[T]) SetAnnotations(anns []Annotation) (err error) {
+	starts := make([]time.Duration, len(f.DataRecords))
+	for idx := range starts {
+		if starts[idx], err = f.RecordStart(idx); err != nil {
+			return err
+		}
+	}
+	return f.writeAnnotations(starts, anns)
+}
@@ -0,0 +1,276 @@
+package biosigio
+
+import (
+	"testing"
+	"time"
+)
+
+// talSamples pads raw TAL bytes to n samples of an EDF annotation signal
+func talSamples(tals string, n int) []int16 {
+	buf := make([]byte, n*EDFDataByteSize)
+	copy(buf, tals)
+	samples, _ := toInt16(buf)
+	return samples
+}
+
+func TestParseTALs(t *testing.T) {
+	tals, err := parseTALs([]byte("+0\x14\x14\x00+1.5\x150.25\x14Lights off\x14Sleep\x14\x00-0.5\x14\x14\x00\x00\x00"))
+	if err != nil {
+		t.Error("For TestParseTALs\n", err)
+		return
+	}
+	if len(tals) != 3 {
+		t.Error("For TestParseTALs\n",
+			"Expected: ", 3,
+			"Got: ", len(tals))
+		return
+	}
+	if tals[1].onset != 1500*time.Millisecond || tals[1].duration != 250*time.Millisecond ||
+		len(tals[1].texts) != 2 || tals[1].texts[1] != "Sleep" {
+		t.Error("For TestParseTALs\n",
+			"Expected: ", "+1.5 0.25 [Lights off Sleep]",
+			"Got: ", tals[1])
+	}
+	if tals[2].onset != -500*time.Millisecond {
+		t.Error("For TestParseTALs\n",
+			"Expected: ", -500*time.Millisecond,
+			"Got: ", tals[2].onset)
+	}
+	for _, bad := range []string{"0\x14\x14\x00", "+1\x14text\x00", "+x\x14\x14\x00", "+1\x14\x14"} {
+		if _, err = parseTALs([]byte(bad)); err == nil {
+			t.Errorf("For TestParseTALs\nExpected error for %q", bad)
+		}
+	}
+}
+
+func TestEDFAnnotations(t *testing.T) {
+	h, err := NewHeader(Version("0"), Reserved("EDF+C"), NumDataRecord("2"),
+		Duration("1"), NumSignal("2"),
+		Labels([]string{"EEG Fpz-Cz", AnnotationsLabel}),
+		NumSamples([]string{"4", "30"}))
+	if err != nil {
+		t.Error("For TestEDFAnnotations\n", err)
+		return
+	}
+	edf := NewEDF(h, []*EDFData{
+		{Signals: [][]int16{make([]int16, 4),
+			talSamples("+0\x14\x14\x00+0.5\x15\x32\x14Lights off\x14\x00", 30)}},
+		{Signals: [][]int16{make([]int16, 4),
+			talSamples("+1.25\x14\x14Recording resumed\x14\x00", 30)}},
+	})
+	if sigs := h.AnnotationSignals(); len(sigs) != 1 || sigs[0] != 1 {
+		t.Error("For TestEDFAnnotations\n",
+			"Expected: ", []int{1},
+			"Got: ", sigs)
+	}
+	start, err := edf.RecordStart(1)
+	if err != nil || start != 1250*time.Millisecond {
+		t.Error("For TestEDFAnnotations\n",
+			"Expected: ", 1250*time.Millisecond,
+			"Got: ", start, err)
+	}
+	anns, err := edf.Annotations()
+	if err != nil {
+		t.Error("For TestEDFAnnotations\n", err)
+		return
+	}
+	expected := []Annotation{
+		{Onset: 500 * time.Millisecond, Duration: 2 * time.Second, Text: "Lights off"},
+		{Onset: 1250 * time.Millisecond, Text: "Recording resumed"},
+	}
+	if len(anns) != len(expected) {
+		t.Error("For TestEDFAnnotations\n",
+			"Expected: ", expected,
+			"Got: ", anns)
+		return
+	}
+	for idx, ann := range anns {
+		if ann != expected[idx] {
+			t.Error("For TestEDFAnnotations\n",
+				"Expected: ", expected[idx],
+				"Got: ", ann)
+		}
+	}
+}
+
+func TestSetAnnotations(t *testing.T) {
+	h, err := NewHeader(Version("0"), NumDataRecord("3"), Duration("2"),
+		NumSignal("1"), NumSamples([]string{"4"}))
+	if err != nil {
+		t.Error("For TestSetAnnotations\n", err)
+		return
+	}
+	records := make([]*EDFData, 3)
+	for idr := range records {
+		n := int16(idr)
+		records[idr] = &EDFData{Signals: [][]int16{{n, n, n, n}}}
+	}
+	edf := NewEDF(h, records)
+	anns := []Annotation{
+		{Onset: 4500 * time.Millisecond, Text: "Seizure end"},
+		{Onset: 2 * time.Second, Duration: 2500 * time.Millisecond, Text: "Seizure"},
+		{Onset: 100 * time.Millisecond, Text: "Lights off"},
+	}
+	if err = edf.SetAnnotations(anns); err != nil {
+		t.Error("For TestSetAnnotations\n", err)
+		return
+	}
+	// The plain EDF header gets EDF+ identifications
+	for _, e := range edf.Validate() {
+		if e.Field == "LPID" || e.Field == "LRID" || e.Field == "reserved" {
+			t.Error("For TestSetAnnotations\n", e)
+		}
+	}
+	if edf.Header.LocalPatientID() != "X X X X" || edf.Header.LocalRecordID() != "Startdate X X X X" {
+		t.Error("For TestSetAnnotations\n",
+			"Expected: ", "X X X X", "Startdate X X X X",
+			"Got: ", edf.Header.LocalPatientID(), edf.Header.LocalRecordID())
+	}
+	// Replacing the annotations keeps a single annotation signal
+	if err = edf.SetAnnotations(anns); err != nil {
+		t.Error("For TestSetAnnotations\n", err)
+		return
+	}
+	buf, err := MarshalEDF(edf)
+	if err != nil {
+		t.Error("For TestSetAnnotations\n", err)
+		return
+	}
+	newEDF, err := UnmarshalEDF(buf)
+	if err != nil {
+		t.Error("For TestSetAnnotations\n", err)
+		return
+	}
+	if sigs := newEDF.Header.AnnotationSignals(); len(sigs) != 1 || sigs[0] != 1 {
+		t.Error("For TestSetAnnotations\n",
+			"Expected: ", []int{1},
+			"Got: ", sigs)
+	}
+	if string(newEDF.Header.reserved[:5]) != "EDF+C" {
+		t.Error("For TestSetAnnotations\n",
+			"Expected: ", "EDF+C",
+			"Got: ", string(newEDF.Header.reserved[:]))
+	}
+	for idr, record := range newEDF.DataRecords {
+		if record.Signals[0][3] != int16(idr) {
+			t.Error("For TestSetAnnotations\n",
+				"Expected: ", idr,
+				"Got: ", record.Signals[0])
+		}
+		start, err := newEDF.RecordStart(idr)
+		if err != nil || start != time.Duration(idr)*2*time.Second {
+			t.Error("For TestSetAnnotations\n",
+				"Expected: ", time.Duration(idr)*2*time.Second,
+				"Got: ", start, err)
+		}
+	}
+	got, err := newEDF.Annotations()
+	if err != nil {
+		t.Error("For TestSetAnnotations\n", err)
+		return
+	}
+	expected := []Annotation{anns[2], anns[1], anns[0]}
+	if len(got) != len(expected) {
+		t.Error("For TestSetAnnotations\n",
+			"Expected: ", expected,
+			"Got: ", got)
+		return
+	}
+	for idx, ann := range got {
+		if ann != expected[idx] {
+			t.Error("For TestSetAnnotations\n",
+				"Expected: ", expected[idx],
+				"Got: ", ann)
+		}
+	}
+	if err = edf.SetAnnotations([]Annotation{{Text: "bad\x14text"}}); err == nil {
+		t.Error("For TestSetAnnotations\n", "Expected error for TAL delimiter in text")
+	}
+}
+
+func TestBDFAnnotations(t *testing.T) {
+	h, err := NewHeader(Version(string(BDFVersion[:])), NumDataRecord("2"), Duration("1"),
+		NumSignal("1"), Labels([]string{"Status"}), NumSamples([]string{"2"}))
+	if err != nil {
+		t.Error("For TestBDFAnnotations\n", err)
+		return
+	}
+	bdf := NewBDF(h, []*BDFData{
+		{Signals: [][]int32{{-8388608, 8388607}}},
+		{Signals: [][]int32{{1, -1}}},
+	})
+	// Text beyond ascii fills samples with their high bit set
+	anns := []Annotation{
+		{Onset: 250 * time.Millisecond, Text: "Trigger 255"},
+		{Onset: 1500 * time.Millisecond, Duration: time.Second, Text: "Göz kırpma"},
+	}
+	if err = bdf.SetAnnotations(anns); err != nil {
+		t.Error("For TestBDFAnnotations\n", err)
+		return
+	}
+	buf, err := MarshalBDF(bdf)
+	if err != nil {
+		t.Error("For TestBDFAnnotations\n", err)
+		return
+	}
+	rec, err := Unmarshal(buf)
+	if err != nil {
+		t.Error("For TestBDFAnnotations\n", err)
+		return
+	}
+	newBDF, ok := rec.(*BDF)
+	if !ok || rec.Format() != FormatBDFPlusC {
+		t.Error("For TestBDFAnnotations\n",
+			"Expected: ", FormatBDFPlusC,
+			"Got: ", rec.Format())
+		return
+	}
+	sigs := newBDF.Header.AnnotationSignals()
+	if len(sigs) != 1 || newBDF.Header.Labels()[sigs[0]] != BDFAnnotationsLabel ||
+		newBDF.Header.DigitalMins()[sigs[0]] != -8388608 || newBDF.Header.DigitalMaxs()[sigs[0]] != 8388607 {
+		t.Error("For TestBDFAnnotations\n",
+			"Expected: ", BDFAnnotationsLabel, -8388608, 8388607,
+			"Got: ", newBDF.Header.Labels(), newBDF.Header.DigitalMins(), newBDF.Header.DigitalMaxs())
+		return
+	}
+	if numsample := newBDF.Header.NumSamples()[sigs[0]]; numsample*BDFDataByteSize < len("+1\x14\x14\x00+1.5\x151\x14Göz kırpma\x14\x00") {
+		t.Error("For TestBDFAnnotations\n",
+			"Expected: ", "annotation signal sized for 3-byte samples",
+			"Got: ", numsample)
+	}
+	got, err := newBDF.Annotations()
+	if err != nil || len(got) != 2 || got[0] != anns[0] || got[1] != anns[1] {
+		t.Error("For TestBDFAnnotations\n",
+			"Expected: ", anns,
+			"Got: ", got, err)
+	}
+	if start, err := newBDF.RecordStart(1); err != nil || start != time.Second {
+		t.Error("For TestBDFAnnotations\n",
+			"Expected: ", time.Second,
+			"Got: ", start, err)
+	}
+	if newBDF.DataRecords[0].Signals[0][0] != -8388608 {
+		t.Error("For TestBDFAnnotations\n",
+			"Expected: ", -8388608,
+			"Got: ", newBDF.DataRecords[0].Signals[0][0])
+	}
+	for _, e := range newBDF.Validate() {
+		if e.Field == "reserved" {
+			t.Error("For TestBDFAnnotations\n", e)
+		}
+	}
+	// An EDF+ reserved field does not match a BDF version
+	if err = Reserved(EDFPlusContinuous)(newBDF.Header); err != nil {
+		t.Error("For TestBDFAnnotations\n", err)
+		return
+	}
+	var found bool
+	for _, e := range newBDF.Header.Validate() {
+		if e.Field == "reserved" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("For TestBDFAnnotations\n", "Expected reserved error for EDF+ in BDF")
+	}
+}
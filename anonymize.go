@@ -0,0 +1,173 @@
+package biosigio
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// AnonymizePolicy chooses which identifying fields Anonymize keeps. Fields
+// not kept are written as unknown, and additional identification subfields
+// are always dropped.
+type AnonymizePolicy struct {
+	KeepCode       bool
+	KeepSex        bool
+	KeepBirthdate  bool
+	KeepName       bool
+	KeepAdminCode  bool
+	KeepTechnician bool
+	KeepEquipment  bool
+	// Pseudonyms maps hospital codes to pseudonymous codes, replacing the
+	// code whether or not it is kept
+	Pseudonyms map[string]string
+	// DateShift moves the start of the recording and the birthdate, keeping
+	// the age of the patient
+	DateShift time.Duration
+	// KeepAnnotation chooses the annotations to keep. When nil every
+	// annotation is dropped.
+	KeepAnnotation func(Annotation) bool
+}
+
+// Change records one value altered by Anonymize
+type Change struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// RandomDateShift returns a shift back in time of between 1 and maxDays
+// whole days, to be drawn once per subject. maxDays must be positive.
+func RandomDateShift(r *rand.Rand, maxDays int) (time.Duration, error) {
+	if maxDays <= 0 {
+		return 0, fmt.Errorf("maximum date shift %v days must be positive", maxDays)
+	}
+	return -time.Duration(1+r.Intn(maxDays)) * 24 * time.Hour, nil
+}
+
+// anonymizer collects the changes made by Anonymize
+type anonymizer struct {
+	changes []Change
+}
+
+func (a *anonymizer) keep(field, old string, keep bool) string {
+	if keep || old == "" {
+		return old
+	}
+	a.changes = append(a.changes, Change{Field: field, Old: old})
+	return ""
+}
+
+// anonymizePatient rewrites the local patient identification
+func (a *anonymizer) anonymizePatient(h *Header, p AnonymizePolicy) error {
+	old := trimField(h.LPID[:])
+	pid, err := ParsePatientID(old)
+	if err != nil {
+		a.changes = append(a.changes, Change{Field: "LPID", Old: old, New: PatientID{}.String()})
+		return h.setLPID(PatientID{}.String())
+	}
+	if code, ok := p.Pseudonyms[pid.Code]; ok {
+		a.changes = append(a.changes, Change{Field: "LPID.Code", Old: pid.Code, New: code})
+		pid.Code = code
+	} else {
+		pid.Code = a.keep("LPID.Code", pid.Code, p.KeepCode)
+	}
+	pid.Sex = a.keep("LPID.Sex", pid.Sex, p.KeepSex)
+	pid.Name = a.keep("LPID.Name", pid.Name, p.KeepName)
+	if !pid.Birthdate.IsZero() {
+		old := formatEDFDate(pid.Birthdate)
+		switch {
+		case !p.KeepBirthdate:
+			pid.Birthdate = time.Time{}
+			a.changes = append(a.changes, Change{Field: "LPID.Birthdate", Old: old})
+		case p.DateShift != 0:
+			pid.Birthdate = pid.Birthdate.Add(p.DateShift)
+			a.changes = append(a.changes, Change{Field: "LPID.Birthdate", Old: old, New: formatEDFDate(pid.Birthdate)})
+		}
+	}
+	for _, val := range pid.Additional {
+		a.changes = append(a.changes, Change{Field: "LPID.Additional", Old: val})
+	}
+	pid.Additional = nil
+	if err = pid.Validate(); err != nil {
+		return err
+	}
+	return h.setLPID(pid.String())
+}
+
+// anonymizeRecording rewrites the local recording identification, leaving
+// its Startdate subfield to setStartTime
+func (a *anonymizer) anonymizeRecording(h *Header, p AnonymizePolicy) error {
+	old := trimField(h.LRID[:])
+	rid, err := ParseRecordingID(old)
+	if err != nil {
+		rid = RecordingID{}
+		rid.Startdate, _ = h.recordingDate()
+		a.changes = append(a.changes, Change{Field: "LRID", Old: old, New: rid.String()})
+		return h.setLRID(rid.String())
+	}
+	rid.AdminCode = a.keep("LRID.AdminCode", rid.AdminCode, p.KeepAdminCode)
+	rid.Technician = a.keep("LRID.Technician", rid.Technician, p.KeepTechnician)
+	rid.Equipment = a.keep("LRID.Equipment", rid.Equipment, p.KeepEquipment)
+	for _, val := range rid.Additional {
+		a.changes = append(a.changes, Change{Field: "LRID.Additional", Old: val})
+	}
+	rid.Additional = nil
+	if err = rid.Validate(); err != nil {
+		return err
+	}
+	return h.setLRID(rid.String())
+}
+
+// Anonymize returns a copy of the file with the patient and recording
+// identification, start of the recording and annotations rewritten by
+// policy p, along with a report of every value changed
+func (f *File[T]) Anonymize(p AnonymizePolicy) (c *File[T], report []Change, err error) {
+	h, err := f.Header.withSignals(f.Header.signalHeaders())
+	if err != nil {
+		return nil, nil, err
+	}
+	a := &anonymizer{}
+	if err = a.anonymizePatient(h, p); err != nil {
+		return nil, nil, fmt.Errorf("LPID: %v", err)
+	}
+	if err = a.anonymizeRecording(h, p); err != nil {
+		return nil, nil, fmt.Errorf("LRID: %v", err)
+	}
+	if p.DateShift != 0 {
+		start, err := f.Header.startTime()
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = h.setStartTime(start.Add(p.DateShift)); err != nil {
+			return nil, nil, err
+		}
+		a.changes = append(a.changes,
+			Change{Field: "startdate", Old: string(f.Header.startdate[:]), New: string(h.startdate[:])},
+			Change{Field: "starttime", Old: string(f.Header.starttime[:]), New: string(h.starttime[:])})
+	}
+
+	records := make([]*Record[T], len(f.DataRecords))
+	for idr, record := range f.DataRecords {
+		records[idr] = record.clone()
+	}
+	c = NewFile(h, records)
+	if len(f.Header.AnnotationSignals()) == 0 || len(f.DataRecords) == 0 {
+		return c, a.changes, nil
+	}
+	starts, anns, err := f.annotationsOf()
+	if err != nil {
+		return nil, nil, err
+	}
+	var kept []Annotation
+	for _, ann := range anns {
+		if p.KeepAnnotation != nil && p.KeepAnnotation(ann) {
+			kept = append(kept, ann)
+			continue
+		}
+		a.changes = append(a.changes, Change{Field: "annotation", Old: ann.Text})
+	}
+	if err = c.writeAnnotations(starts, kept); err != nil {
+		return nil, nil, err
+	}
+	return c, a.changes, nil
+}
@@ -0,0 +1,105 @@
+package biosigio
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestAnonymize(t *testing.T) {
+	edf, err := plusEDF(2, []Annotation{
+		{Onset: 100 * time.Millisecond, Text: "Lights off"},
+		{Onset: 600 * time.Millisecond, Text: "John said hi"},
+	})
+	if err != nil {
+		t.Error("For TestAnonymize\n", err)
+		return
+	}
+	for _, opt := range []func(*Header) error{
+		LocalPatientID("MCH-0234567 F 02-MAY-1951 Haagse_Harry"),
+		LocalRecordID("Startdate 12-JUL-2015 PSG01 Dr_Smith Embla"),
+	} {
+		if err = opt(edf.Header); err != nil {
+			t.Error("For TestAnonymize\n", err)
+			return
+		}
+	}
+	c, report, err := edf.Anonymize(AnonymizePolicy{
+		KeepSex:        true,
+		KeepBirthdate:  true,
+		KeepEquipment:  true,
+		Pseudonyms:     map[string]string{"MCH-0234567": "SUBJ-001"},
+		DateShift:      -10 * 24 * time.Hour,
+		KeepAnnotation: func(ann Annotation) bool { return ann.Text == "Lights off" },
+	})
+	if err != nil {
+		t.Error("For TestAnonymize\n", err)
+		return
+	}
+	if lpid := c.Header.LocalPatientID(); lpid != "SUBJ-001 F 22-APR-1951 X" {
+		t.Error("For TestAnonymize\n",
+			"Expected: ", "SUBJ-001 F 22-APR-1951 X",
+			"Got: ", lpid)
+	}
+	if lrid := c.Header.LocalRecordID(); lrid != "Startdate 02-JUL-2015 X X Embla" {
+		t.Error("For TestAnonymize\n",
+			"Expected: ", "Startdate 02-JUL-2015 X X Embla",
+			"Got: ", lrid)
+	}
+	expected := time.Date(2015, time.July, 2, 21, 18, 32, 0, time.UTC)
+	if !c.Header.StartTime().Equal(expected) {
+		t.Error("For TestAnonymize\n",
+			"Expected: ", expected,
+			"Got: ", c.Header.StartTime())
+	}
+	anns, err := c.Annotations()
+	if err != nil || len(anns) != 1 || anns[0].Text != "Lights off" {
+		t.Error("For TestAnonymize\n",
+			"Expected: ", "Lights off",
+			"Got: ", anns, err)
+	}
+	fields := make(map[string]Change)
+	for _, change := range report {
+		fields[change.Field] = change
+	}
+	for _, field := range []string{"LPID.Code", "LPID.Name", "LPID.Birthdate", "LRID.AdminCode",
+		"LRID.Technician", "startdate", "annotation"} {
+		if _, ok := fields[field]; !ok {
+			t.Error("For TestAnonymize\n", "Expected change to ", field, " in ", report)
+		}
+	}
+	if fields["LPID.Name"].Old != "Haagse Harry" || fields["annotation"].Old != "John said hi" {
+		t.Error("For TestAnonymize\n",
+			"Expected: ", "Haagse Harry", "John said hi",
+			"Got: ", fields["LPID.Name"], fields["annotation"])
+	}
+	// The original file is left untouched
+	if edf.Header.LocalPatientID() != "MCH-0234567 F 02-MAY-1951 Haagse_Harry" {
+		t.Error("For TestAnonymize\n",
+			"Expected: ", "MCH-0234567 F 02-MAY-1951 Haagse_Harry",
+			"Got: ", edf.Header.LocalPatientID())
+	}
+
+	// Free text identification is dropped whole
+	if err = LocalPatientID("Haagse Harry, born 1951")(edf.Header); err != nil {
+		t.Error("For TestAnonymize\n", err)
+		return
+	}
+	c, _, err = edf.Anonymize(AnonymizePolicy{})
+	if err != nil || c.Header.LocalPatientID() != "X X X X" {
+		t.Error("For TestAnonymize\n",
+			"Expected: ", "X X X X",
+			"Got: ", c.Header.LocalPatientID(), err)
+	}
+	r := rand.New(rand.NewSource(1))
+	if shift, err := RandomDateShift(r, 30); err != nil || shift > -24*time.Hour || shift < -30*24*time.Hour {
+		t.Error("For TestAnonymize\n",
+			"Expected: ", "shift of 1 to 30 days back",
+			"Got: ", shift, err)
+	}
+	for _, maxDays := range []int{0, -1} {
+		if _, err = RandomDateShift(r, maxDays); err == nil {
+			t.Error("For TestAnonymize\n", "Expected error for maximum shift of ", maxDays, " days")
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package biosigio
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// compatible checks that data records of h and o hold the same signals with
+// the same sample counts, physical dimensions and ranges, and last as long.
+// The sample counts of annotation signals may differ.
+func (h *Header) compatible(o *Header) error {
+	if h.version != o.version {
+		return fmt.Errorf("version %q differs from %q", o.version[:], h.version[:])
+	}
+	if h.duration != o.duration {
+		return fmt.Errorf("duration %q differs from %q", o.duration[:], h.duration[:])
+	}
+	if len(h.label) != len(o.label) {
+		return fmt.Errorf("number of signals [%v] differs from [%v]", len(o.label), len(h.label))
+	}
+	for idx := range h.label {
+		if h.label[idx] != o.label[idx] {
+			return fmt.Errorf("signal %v label %q differs from %q", idx, o.label[idx][:], h.label[idx][:])
+		}
+		if isAnnotationLabel(trimField(h.label[idx][:])) {
+			continue
+		}
+		switch {
+		case h.numsample[idx] != o.numsample[idx]:
+			return fmt.Errorf("signal %v number of samples %q differs from %q",
+				idx, o.numsample[idx][:], h.numsample[idx][:])
+		case h.phydim[idx] != o.phydim[idx]:
+			return fmt.Errorf("signal %v physical dimension %q differs from %q",
+				idx, o.phydim[idx][:], h.phydim[idx][:])
+		case h.phymin[idx] != o.phymin[idx] || h.phymax[idx] != o.phymax[idx]:
+			return fmt.Errorf("signal %v physical range [%s, %s] differs from [%s, %s]", idx,
+				o.phymin[idx][:], o.phymax[idx][:], h.phymin[idx][:], h.phymax[idx][:])
+		case h.digmin[idx] != o.digmin[idx] || h.digmax[idx] != o.digmax[idx]:
+			return fmt.Errorf("signal %v digital range [%s, %s] differs from [%s, %s]", idx,
+				o.digmin[idx][:], o.digmax[idx][:], h.digmin[idx][:], h.digmax[idx][:])
+		}
+	}
+	return nil
+}
+
+// Concat joins the data records of files in order into a new file, taking
+// the header of the first. The files must hold the same signals with the
+// same sample counts, physical dimensions, physical and digital ranges and
+// data record duration, and must not overlap in time. When the data records
+// are not contiguous in time the new file is EDF+D or BDF+D, with the start
+// of each data record given by its time-keeping annotation. EDF+ annotations
+// are carried over relative to the start of the first file.
+func Concat[T Sample](files ...*File[T]) (*File[T], error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files to concatenate")
+	}
+	first := files[0].Header
+	duration, err := asciiToDuration(first.duration[:])
+	if err != nil {
+		return nil, fmt.Errorf("serialize ascii to duration: %v, for %v", err, first.duration)
+	}
+	origin, err := first.startTime()
+	if err != nil {
+		return nil, fmt.Errorf("file 0: %v", err)
+	}
+	var starts []time.Duration
+	var anns []Annotation
+	var records []*Record[T]
+	plus := false
+	for idf, f := range files {
+		if err = first.compatible(f.Header); err != nil {
+			return nil, fmt.Errorf("file %v: %v", idf, err)
+		}
+		start, err := f.Header.startTime()
+		if err != nil {
+			return nil, fmt.Errorf("file %v: %v", idf, err)
+		}
+		offset := start.Sub(origin)
+		for idr, record := range f.DataRecords {
+			rstart, err := f.RecordStart(idr)
+			if err != nil {
+				return nil, fmt.Errorf("file %v: %v", idf, err)
+			}
+			rstart += offset
+			if n := len(starts); n > 0 && rstart < starts[n-1]+duration {
+				return nil, fmt.Errorf("file %v data record %v starts at %v, before the end of the previous at %v",
+					idf, idr, rstart, starts[n-1]+duration)
+			}
+			starts = append(starts, rstart)
+			records = append(records, record.clone())
+		}
+		if len(f.Header.AnnotationSignals()) > 0 {
+			plus = true
+			fileAnns, err := f.Annotations()
+			if err != nil {
+				return nil, fmt.Errorf("file %v: %v", idf, err)
+			}
+			for _, ann := range fileAnns {
+				ann.Onset += offset
+				anns = append(anns, ann)
+			}
+		}
+	}
+
+	contiguous := true
+	for idx := 1; idx < len(starts); idx++ {
+		if starts[idx] != starts[idx-1]+duration {
+			contiguous = false
+		}
+	}
+	h, err := first.withSignals(first.signalHeaders())
+	if err != nil {
+		return nil, err
+	}
+	if err = h.setNumDataRecord(strconv.Itoa(len(records))); err != nil {
+		return nil, err
+	}
+	c := NewFile(h, records)
+	if !plus && contiguous {
+		return c, nil
+	}
+	if err = c.writeAnnotations(starts, anns); err != nil {
+		return nil, err
+	}
+	if err = c.Header.setReserved(plusReserved(sampleSize[T](), !contiguous)); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ConcatEDF joins the data records of EDF files in order into a new file
+func ConcatEDF(files ...*EDF) (*EDF, error) {
+	return Concat(files...)
+}
+
+// ConcatBDF joins the data records of BDF files in order into a new file
+func ConcatBDF(files ...*BDF) (*BDF, error) {
+	return Concat(files...)
+}
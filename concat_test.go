@@ -0,0 +1,107 @@
+package biosigio
+
+import (
+	"testing"
+	"time"
+)
+
+// hourEDF builds an EDF file of two 1s data records starting at starttime
+func hourEDF(starttime string, label string) (*EDF, error) {
+	h, err := NewHeader(Version("0"), Startdate("12.07.15"), Starttime(starttime),
+		NumDataRecord("2"), Duration("1"), NumSignal("1"),
+		Labels([]string{label}), NumSamples([]string{"2"}),
+		PhysicalMins([]string{"-100"}), PhysicalMaxs([]string{"100"}),
+		DigitalMins([]string{"-100"}), DigitalMaxs([]string{"100"}))
+	if err != nil {
+		return nil, err
+	}
+	return NewEDF(h, []*EDFData{
+		{Signals: [][]int16{{1, 2}}},
+		{Signals: [][]int16{{3, 4}}},
+	}), nil
+}
+
+func TestConcat(t *testing.T) {
+	var files []*EDF
+	for _, starttime := range []string{"10.00.00", "10.00.02", "10.00.10"} {
+		edf, err := hourEDF(starttime, "EEG Fpz-Cz")
+		if err != nil {
+			t.Error("For TestConcat\n", err)
+			return
+		}
+		files = append(files, edf)
+	}
+	c, err := ConcatEDF(files[0], files[1])
+	if err != nil {
+		t.Error("For TestConcat\n", err)
+		return
+	}
+	if c.Header.NumDataRecords() != 4 || len(c.DataRecords) != 4 || c.Header.EDFPlus() ||
+		c.DataRecords[3].Signals[0][1] != 4 {
+		t.Error("For TestConcat\n",
+			"Expected: ", 4, "EDF",
+			"Got: ", c.Header.NumDataRecords(), c.Header.Reserved())
+	}
+
+	c, err = ConcatEDF(files...)
+	if err != nil {
+		t.Error("For TestConcat\n", err)
+		return
+	}
+	if c.Header.Reserved() != EDFPlusDiscontinuous || c.Header.NumDataRecords() != 6 {
+		t.Error("For TestConcat\n",
+			"Expected: ", EDFPlusDiscontinuous, 6,
+			"Got: ", c.Header.Reserved(), c.Header.NumDataRecords())
+	}
+	// Plain EDF inputs get EDF+ identifications so the output is valid EDF+D
+	if errs := c.Validate(); len(errs) != 0 || c.Header.LocalRecordID() != "Startdate 12-JUL-2015 X X X" {
+		t.Error("For TestConcat\n",
+			"Expected: ", "no errors", "Startdate 12-JUL-2015 X X X",
+			"Got: ", errs, c.Header.LocalRecordID())
+	}
+	buf, err := MarshalEDF(c)
+	if err != nil {
+		t.Error("For TestConcat\n", err)
+		return
+	}
+	newEDF, err := UnmarshalEDF(buf)
+	if err != nil {
+		t.Error("For TestConcat\n", err)
+		return
+	}
+	segs, err := newEDF.Segments()
+	if err != nil {
+		t.Error("For TestConcat\n", err)
+		return
+	}
+	expected := []Segment{
+		{Start: 0, Duration: 4 * time.Second, First: 0, Last: 4},
+		{Start: 10 * time.Second, Duration: 2 * time.Second, First: 4, Last: 6},
+	}
+	if len(segs) != len(expected) || segs[0] != expected[0] || segs[1] != expected[1] {
+		t.Error("For TestConcat\n",
+			"Expected: ", expected,
+			"Got: ", segs)
+	}
+
+	if _, err = ConcatEDF(files[1], files[0]); err == nil {
+		t.Error("For TestConcat\n", "Expected error for files out of order")
+	}
+	other, err := hourEDF("10.00.20", "ECG")
+	if err != nil {
+		t.Error("For TestConcat\n", err)
+		return
+	}
+	if _, err = ConcatEDF(files[0], other); err == nil {
+		t.Error("For TestConcat\n", "Expected error for different labels")
+	}
+	for _, dims := range [][]string{{"uV"}, {"mV"}} {
+		if err = files[2].Header.setPhysicalDimensions(dims); err != nil {
+			t.Error("For TestConcat\n", err)
+			return
+		}
+		if _, err = ConcatEDF(files[0], files[2]); err == nil {
+			t.Error("For TestConcat\n", "Expected error for physical dimension ", dims)
+		}
+	}
+}
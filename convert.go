@@ -0,0 +1,224 @@
+package biosigio
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// edfWidening is the factor between the digital values of an EDF signal and
+// those of the same signal converted to BDF
+const edfWidening = 1 << 8
+
+// ConvertMode chooses how BDF signals whose digital range does not fit in
+// 2 bytes are converted to EDF
+type ConvertMode int
+
+const (
+	// ConvertClip keeps the digital values and their physical units, clipping
+	// the digital and physical range to the EDF range. Samples outside the
+	// range saturate.
+	ConvertClip ConvertMode = iota
+	// ConvertRescale keeps the physical range, re-quantizing the samples
+	// onto the EDF digital range
+	ConvertRescale
+)
+
+// Loss describes the precision lost converting one signal
+type Loss struct {
+	// Clipped counts the samples saturated at the digital range
+	Clipped int
+	// MaxError is the largest difference in physical units between a sample
+	// and its conversion
+	MaxError float64
+}
+
+// annotationsOf returns the start of each data record and the annotations of
+// a file holding annotation signals
+func (f *File[T]) annotationsOf() (starts []time.Duration, anns []Annotation, err error) {
+	starts = make([]time.Duration, len(f.DataRecords))
+	for idr := range starts {
+		if starts[idr], err = f.RecordStart(idr); err != nil {
+			return nil, nil, err
+		}
+	}
+	if anns, err = f.Annotations(); err != nil {
+		return nil, nil, err
+	}
+	return starts, anns, nil
+}
+
+// setDigitalRange writes the digital range of signal idx
+func (h *Header) setDigitalRange(idx, digmin, digmax int) error {
+	if err := setField(h.digmin[idx][:], strconv.Itoa(digmin)); err != nil {
+		return err
+	}
+	return setField(h.digmax[idx][:], strconv.Itoa(digmax))
+}
+
+// setPhysicalRange writes the physical range of signal idx
+func (h *Header) setPhysicalRange(idx int, phymin, phymax float64) error {
+	for _, field := range []struct {
+		buf []byte
+		val float64
+	}{{h.phymin[idx][:], phymin}, {h.phymax[idx][:], phymax}} {
+		s, err := floatToASCII(field.val, len(field.buf))
+		if err != nil {
+			return err
+		}
+		if err = setField(field.buf, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToBDF converts an EDF file to BDF without loss. The digital values and
+// range of each signal are multiplied by 256, leaving the physical values
+// unchanged. Annotations are rewritten to fit 3-byte samples.
+func ToBDF(edf *EDF) (*BDF, error) {
+	h, err := edf.Header.withSignals(edf.Header.signalHeaders())
+	if err != nil {
+		return nil, err
+	}
+	h.version = BDFVersion
+	scales, isAnn, err := edf.Header.scales()
+	if err != nil {
+		return nil, err
+	}
+	for idx, s := range scales {
+		if isAnn[idx] {
+			continue
+		}
+		if err = h.setDigitalRange(idx, s.digmin*edfWidening, s.digmax*edfWidening); err != nil {
+			return nil, err
+		}
+	}
+	records := make([]*BDFData, len(edf.DataRecords))
+	for idr, record := range edf.DataRecords {
+		signals := make([][]int32, len(record.Signals))
+		for ids, signal := range record.Signals {
+			if isAnn[ids] {
+				continue
+			}
+			signals[ids] = make([]int32, len(signal))
+			for idy, val := range signal {
+				signals[ids][idy] = int32(val) * edfWidening
+			}
+		}
+		records[idr] = &BDFData{Signals: signals}
+	}
+	bdf := NewBDF(h, records)
+	if len(isAnn) == 0 {
+		return bdf, nil
+	}
+	starts, anns, err := edf.annotationsOf()
+	if err != nil {
+		return nil, err
+	}
+	if err = bdf.writeAnnotations(starts, anns); err != nil {
+		return nil, err
+	}
+	return bdf, nil
+}
+
+// ToEDF converts a BDF file to EDF. Signals whose digital range fits in 2
+// bytes are converted without loss, others according to mode. The loss of
+// each signal of bdf is reported, annotation signals reporting none.
+func ToEDF(bdf *BDF, mode ConvertMode) (*EDF, []Loss, error) {
+	h, err := bdf.Header.withSignals(bdf.Header.signalHeaders())
+	if err != nil {
+		return nil, nil, err
+	}
+	h.version = EDFVersion
+	scales, isAnn, err := bdf.Header.scales()
+	if err != nil {
+		return nil, nil, err
+	}
+	// convs maps the digital values of each signal, counting clipped samples
+	convs := make([]func(int32) (int16, bool), len(scales))
+	newScales := make([]scale, len(scales))
+	for idx, s := range scales {
+		if isAnn[idx] {
+			continue
+		}
+		digmin, digmax := s.digmin, s.digmax
+		switch {
+		case digmin >= edfSampleMin && digmax <= edfSampleMax:
+		case mode == ConvertClip:
+			if digmin < edfSampleMin {
+				digmin = edfSampleMin
+			}
+			if digmax > edfSampleMax {
+				digmax = edfSampleMax
+			}
+			if err = h.setPhysicalRange(idx, s.physical(digmin), s.physical(digmax)); err != nil {
+				return nil, nil, fmt.Errorf("signal %v: %v", idx, err)
+			}
+		case mode == ConvertRescale:
+			digmin, digmax = edfSampleMin, edfSampleMax
+		default:
+			return nil, nil, fmt.Errorf("unknown conversion mode %v", mode)
+		}
+		if err = h.setDigitalRange(idx, digmin, digmax); err != nil {
+			return nil, nil, err
+		}
+		if newScales[idx], err = h.signalScale(idx); err != nil {
+			return nil, nil, err
+		}
+		from, to := s, newScales[idx]
+		if mode == ConvertRescale && (from.digmin != to.digmin || from.digmax != to.digmax) {
+			convs[idx] = func(val int32) (int16, bool) {
+				clipped := int(val) < from.digmin || int(val) > from.digmax
+				return int16(to.digital(from.physical(int(val)))), clipped
+			}
+			continue
+		}
+		convs[idx] = func(val int32) (int16, bool) {
+			switch {
+			case int(val) < to.digmin:
+				return int16(to.digmin), true
+			case int(val) > to.digmax:
+				return int16(to.digmax), true
+			}
+			return int16(val), false
+		}
+	}
+
+	losses := make([]Loss, len(scales))
+	records := make([]*EDFData, len(bdf.DataRecords))
+	for idr, record := range bdf.DataRecords {
+		signals := make([][]int16, len(record.Signals))
+		for ids, signal := range record.Signals {
+			if isAnn[ids] {
+				continue
+			}
+			signals[ids] = make([]int16, len(signal))
+			for idy, val := range signal {
+				d, clipped := convs[ids](val)
+				signals[ids][idy] = d
+				if clipped {
+					losses[ids].Clipped++
+				}
+				diff := math.Abs(scales[ids].physical(int(val)) - newScales[ids].physical(int(d)))
+				if diff > losses[ids].MaxError {
+					losses[ids].MaxError = diff
+				}
+			}
+		}
+		records[idr] = &EDFData{Signals: signals}
+	}
+	edf := NewEDF(h, records)
+	if len(isAnn) == 0 {
+		return edf, losses, nil
+	}
+	starts, anns, err := bdf.annotationsOf()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = edf.writeAnnotations(starts, anns); err != nil {
+		return nil, nil, err
+	}
+	return edf, losses, nil
+}
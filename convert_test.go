@@ -0,0 +1,146 @@
+package biosigio
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestToBDF(t *testing.T) {
+	anns := []Annotation{{Onset: 600 * time.Millisecond, Text: "Lights off"}}
+	edf, err := plusEDF(3, anns)
+	if err != nil {
+		t.Error("For TestToBDF\n", err)
+		return
+	}
+	for _, opt := range []func(*Header) error{
+		PhysicalMins([]string{"-100", "-1"}), PhysicalMaxs([]string{"100", "1"}),
+		DigitalMins([]string{"-32768", "-32768"}), DigitalMaxs([]string{"32767", "32767"}),
+	} {
+		if err = opt(edf.Header); err != nil {
+			t.Error("For TestToBDF\n", err)
+			return
+		}
+	}
+	edf.DataRecords[1].Signals[0] = []int16{-32768, 32767}
+	bdf, err := ToBDF(edf)
+	if err != nil {
+		t.Error("For TestToBDF\n", err)
+		return
+	}
+	if bdf.Header.Version() != string(BDFVersion[:]) {
+		t.Error("For TestToBDF\n",
+			"Expected: ", string(BDFVersion[:]),
+			"Got: ", bdf.Header.Version())
+	}
+	if mins := bdf.Header.DigitalMins(); mins[0] != -8388608 {
+		t.Error("For TestToBDF\n",
+			"Expected: ", -8388608,
+			"Got: ", mins[0])
+	}
+	for idr := range edf.DataRecords {
+		want, _ := edf.Physical(idr)
+		got, err := bdf.Physical(idr)
+		if err != nil {
+			t.Error("For TestToBDF\n", err)
+			return
+		}
+		for idy, val := range want[0] {
+			if math.Abs(got[0][idy]-val) > 1e-9 {
+				t.Error("For TestToBDF\n",
+					"Expected: ", want[0],
+					"Got: ", got[0])
+			}
+		}
+	}
+	got, err := bdf.Annotations()
+	if err != nil || len(got) != 1 || got[0] != anns[0] {
+		t.Error("For TestToBDF\n",
+			"Expected: ", anns,
+			"Got: ", got, err)
+	}
+	buf, err := MarshalBDF(bdf)
+	if err != nil {
+		t.Error("For TestToBDF\n", err)
+		return
+	}
+	if _, err = UnmarshalBDF(buf); err != nil {
+		t.Error("For TestToBDF\n", err)
+	}
+
+	// Widened EDF converts back without loss
+	back, losses, err := ToEDF(bdf, ConvertRescale)
+	if err != nil {
+		t.Error("For TestToBDF\n", err)
+		return
+	}
+	if losses[0].Clipped != 0 || losses[0].MaxError > 1e-9 || back.DataRecords[1].Signals[0][1] != 32767 {
+		t.Error("For TestToBDF\n",
+			"Expected: ", Loss{}, 32767,
+			"Got: ", losses[0], back.DataRecords[1].Signals[0])
+	}
+}
+
+func TestToEDF(t *testing.T) {
+	h, err := NewHeader(Version(string(BDFVersion[:])), NumDataRecord("1"), Duration("1"),
+		NumSignal("1"), PhysicalMins([]string{"-8388608"}), PhysicalMaxs([]string{"8388607"}),
+		DigitalMins([]string{"-8388608"}), DigitalMaxs([]string{"8388607"}),
+		NumSamples([]string{"4"}))
+	if err != nil {
+		t.Error("For TestToEDF\n", err)
+		return
+	}
+	bdf := NewBDF(h, []*BDFData{{Signals: [][]int32{{0, 100000, -5, 40000}}}})
+
+	edf, losses, err := ToEDF(bdf, ConvertClip)
+	if err != nil {
+		t.Error("For TestToEDF\n", err)
+		return
+	}
+	expected := []int16{0, 32767, -5, 32767}
+	for idy, val := range edf.DataRecords[0].Signals[0] {
+		if val != expected[idy] {
+			t.Error("For TestToEDF\n",
+				"Expected: ", expected,
+				"Got: ", edf.DataRecords[0].Signals[0])
+			break
+		}
+	}
+	if losses[0].Clipped != 2 || math.Abs(losses[0].MaxError-(100000-32767)) > 1e-6 {
+		t.Error("For TestToEDF\n",
+			"Expected: ", Loss{Clipped: 2, MaxError: 100000 - 32767},
+			"Got: ", losses[0])
+	}
+	if maxs := edf.Header.PhysicalMaxs(); maxs[0] != 32767 {
+		t.Error("For TestToEDF\n",
+			"Expected: ", 32767,
+			"Got: ", maxs[0])
+	}
+
+	edf, losses, err = ToEDF(bdf, ConvertRescale)
+	if err != nil {
+		t.Error("For TestToEDF\n", err)
+		return
+	}
+	gain := 16777215.0 / 65535
+	if losses[0].Clipped != 0 || losses[0].MaxError == 0 || losses[0].MaxError > gain/2+1e-6 {
+		t.Error("For TestToEDF\n",
+			"Expected: ", "no clipping and error within ", gain/2,
+			"Got: ", losses[0])
+	}
+	physical, err := edf.Physical(0)
+	if err != nil {
+		t.Error("For TestToEDF\n", err)
+		return
+	}
+	if math.Abs(physical[0][1]-100000) > gain/2+1e-6 {
+		t.Error("For TestToEDF\n",
+			"Expected: ", 100000,
+			"Got: ", physical[0][1])
+	}
+	if edf.Header.Version() != "0" {
+		t.Error("For TestToEDF\n",
+			"Expected: ", "0",
+			"Got: ", edf.Header.Version())
+	}
+}
@@ -0,0 +1,118 @@
+package biosigio
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// clone returns a copy of the data record sharing no samples with d
+func (d *Record[T]) clone() *Record[T] {
+	signals := make([][]T, len(d.Signals))
+	for idx, signal := range d.Signals {
+		signals[idx] = append([]T(nil), signal...)
+	}
+	return &Record[T]{Signals: signals}
+}
+
+// extract returns a new file holding copies of the data records idxs, which
+// start at starts relative to the start of the recording. The new recording
+// starts at starts[0] truncated to the second. The fraction of a second is
+// kept in the time-keeping annotations of EDF+ files, along with the
+// annotations falling in the extracted data records. Plain EDF files have no
+// place for the fraction, so their first data record must start on a second.
+func (f *File[T]) extract(idxs []int, starts []time.Duration) (*File[T], error) {
+	if len(idxs) == 0 {
+		return nil, fmt.Errorf("no data records to extract")
+	}
+	duration, err := asciiToDuration(f.Header.duration[:])
+	if err != nil {
+		return nil, fmt.Errorf("serialize ascii to duration: %v, for %v", err, f.Header.duration)
+	}
+	h, err := f.Header.withSignals(f.Header.signalHeaders())
+	if err != nil {
+		return nil, err
+	}
+	if err = h.setNumDataRecord(strconv.Itoa(len(idxs))); err != nil {
+		return nil, err
+	}
+	offset := starts[0]
+	start, err := f.Header.startTime()
+	if err != nil {
+		return nil, err
+	}
+	t := start.Add(offset)
+	if err = h.setStartTime(t.Truncate(time.Second)); err != nil {
+		return nil, err
+	}
+	frac := t.Sub(t.Truncate(time.Second))
+	if frac != 0 && !f.Header.EDFPlus() {
+		return nil, fmt.Errorf("data record %v starts %v past the second, which only EDF+ and BDF+ files can hold",
+			idxs[0], frac)
+	}
+	records := make([]*Record[T], len(idxs))
+	for idx, idr := range idxs {
+		records[idx] = f.DataRecords[idr].clone()
+	}
+	c := NewFile(h, records)
+	if !f.Header.EDFPlus() {
+		return c, nil
+	}
+
+	anns, err := f.Annotations()
+	if err != nil {
+		return nil, err
+	}
+	var kept []Annotation
+	for _, ann := range anns {
+		if ann.Onset >= offset && ann.Onset < starts[len(starts)-1]+duration {
+			ann.Onset += frac - offset
+			kept = append(kept, ann)
+		}
+	}
+	rebased := make([]time.Duration, len(starts))
+	for idx, start := range starts {
+		rebased[idx] = start + frac - offset
+	}
+	if err = c.writeAnnotations(rebased, kept); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Crop returns a new file holding copies of the data records overlapping
+// [start, end), measured from the start of the recording. Data records are
+// kept whole, so the new file starts at offset, the start of the first data
+// record kept, which may fall before start. The startdate and starttime are
+// moved to offset, truncated to the second, and EDF+ annotations within the
+// data records kept are carried over relative to the new start. Cropping a
+// plain EDF file fails when offset does not fall on a whole second, and any
+// file fails when its startdate or starttime does not parse.
+func (f *File[T]) Crop(start, end time.Duration) (c *File[T], offset time.Duration, err error) {
+	if end <= start {
+		return nil, 0, fmt.Errorf("end %v must be after start %v", end, start)
+	}
+	duration, err := asciiToDuration(f.Header.duration[:])
+	if err != nil {
+		return nil, 0, fmt.Errorf("serialize ascii to duration: %v, for %v", err, f.Header.duration)
+	}
+	var idxs []int
+	var starts []time.Duration
+	for idr := range f.DataRecords {
+		rstart, err := f.RecordStart(idr)
+		if err != nil {
+			return nil, 0, err
+		}
+		if rstart < end && rstart+duration > start {
+			idxs = append(idxs, idr)
+			starts = append(starts, rstart)
+		}
+	}
+	if len(idxs) == 0 {
+		return nil, 0, fmt.Errorf("no data records in [%v, %v)", start, end)
+	}
+	if c, err = f.extract(idxs, starts); err != nil {
+		return nil, 0, err
+	}
+	return c, starts[0], nil
+}
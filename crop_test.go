@@ -0,0 +1,145 @@
+package biosigio
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// plusEDF builds an EDF+C file of n data records of 0.5s, the samples of
+// data record idr all equal to idr
+func plusEDF(n int, anns []Annotation) (*EDF, error) {
+	h, err := NewHeader(Version("0"), Startdate("12.07.15"), Starttime("21.18.32"),
+		NumDataRecord(strconv.Itoa(n)), Duration("0.5"), NumSignal("1"),
+		Labels([]string{"EEG Fpz-Cz"}), NumSamples([]string{"2"}))
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*EDFData, n)
+	for idr := range records {
+		records[idr] = &EDFData{Signals: [][]int16{{int16(idr), int16(idr)}}}
+	}
+	edf := NewEDF(h, records)
+	if err = edf.SetAnnotations(anns); err != nil {
+		return nil, err
+	}
+	return edf, nil
+}
+
+func TestCrop(t *testing.T) {
+	edf, err := plusEDF(4, []Annotation{
+		{Onset: 600 * time.Millisecond, Text: "A"},
+		{Onset: 1600 * time.Millisecond, Text: "B"},
+	})
+	if err != nil {
+		t.Error("For TestCrop\n", err)
+		return
+	}
+	c, offset, err := edf.Crop(1200*time.Millisecond, 1700*time.Millisecond)
+	if err != nil {
+		t.Error("For TestCrop\n", err)
+		return
+	}
+	if offset != time.Second || c.Header.NumDataRecords() != 2 || len(c.DataRecords) != 2 ||
+		c.DataRecords[0].Signals[0][0] != 2 {
+		t.Error("For TestCrop\n",
+			"Expected: ", time.Second, 2, 2,
+			"Got: ", offset, c.Header.NumDataRecords(), c.DataRecords[0].Signals[0][0])
+	}
+	expected := time.Date(2015, time.July, 12, 21, 18, 33, 0, time.UTC)
+	if !c.Header.StartTime().Equal(expected) {
+		t.Error("For TestCrop\n",
+			"Expected: ", expected,
+			"Got: ", c.Header.StartTime())
+	}
+	anns, err := c.Annotations()
+	if err != nil || len(anns) != 1 || anns[0].Text != "B" || anns[0].Onset != 600*time.Millisecond {
+		t.Error("For TestCrop\n",
+			"Expected: ", []Annotation{{Onset: 600 * time.Millisecond, Text: "B"}},
+			"Got: ", anns, err)
+	}
+
+	// A data record starting within a second keeps the fraction in its
+	// time-keeping annotation
+	c, offset, err = edf.Crop(600*time.Millisecond, 900*time.Millisecond)
+	if err != nil {
+		t.Error("For TestCrop\n", err)
+		return
+	}
+	start, err := c.RecordStart(0)
+	if offset != 500*time.Millisecond || err != nil || start != 500*time.Millisecond {
+		t.Error("For TestCrop\n",
+			"Expected: ", 500*time.Millisecond, 500*time.Millisecond,
+			"Got: ", offset, start, err)
+	}
+	if !c.Header.StartTime().Equal(expected.Add(-time.Second)) {
+		t.Error("For TestCrop\n",
+			"Expected: ", expected.Add(-time.Second),
+			"Got: ", c.Header.StartTime())
+	}
+	anns, err = c.Annotations()
+	if err != nil || len(anns) != 1 || anns[0].Text != "A" || anns[0].Onset != 600*time.Millisecond {
+		t.Error("For TestCrop\n",
+			"Expected: ", []Annotation{{Onset: 600 * time.Millisecond, Text: "A"}},
+			"Got: ", anns, err)
+	}
+	// The original file is left untouched
+	if edf.Header.NumDataRecords() != 4 || !edf.Header.StartTime().Equal(expected.Add(-time.Second)) {
+		t.Error("For TestCrop\n",
+			"Expected: ", 4, expected.Add(-time.Second),
+			"Got: ", edf.Header.NumDataRecords(), edf.Header.StartTime())
+	}
+	if _, _, err = edf.Crop(3*time.Second, 4*time.Second); err == nil {
+		t.Error("For TestCrop\n", "Expected error for window past the end")
+	}
+	if _, _, err = edf.Crop(time.Second, time.Second); err == nil {
+		t.Error("For TestCrop\n", "Expected error for empty window")
+	}
+}
+
+// plainEDF builds a plain EDF file of n data records of 0.5s, the samples of
+// data record idr all equal to idr
+func plainEDF(n int) (*EDF, error) {
+	h, err := NewHeader(Version("0"), Startdate("12.07.15"), Starttime("21.18.32"),
+		NumDataRecord(strconv.Itoa(n)), Duration("0.5"), NumSignal("1"),
+		Labels([]string{"EEG Fpz-Cz"}), NumSamples([]string{"2"}))
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*EDFData, n)
+	for idr := range records {
+		records[idr] = &EDFData{Signals: [][]int16{{int16(idr), int16(idr)}}}
+	}
+	return NewEDF(h, records), nil
+}
+
+func TestCropPlainEDF(t *testing.T) {
+	edf, err := plainEDF(4)
+	if err != nil {
+		t.Error("For TestCropPlainEDF\n", err)
+		return
+	}
+	// A plain EDF file has no place for a start past the second
+	if _, _, err = edf.Crop(1500*time.Millisecond, 2*time.Second); err == nil {
+		t.Error("For TestCropPlainEDF\n", "Expected error for start at 1.5s")
+	}
+	c, offset, err := edf.Crop(time.Second, 2*time.Second)
+	if err != nil {
+		t.Error("For TestCropPlainEDF\n", err)
+		return
+	}
+	expected := time.Date(2015, time.July, 12, 21, 18, 33, 0, time.UTC)
+	if offset != time.Second || len(c.DataRecords) != 2 || !c.Header.StartTime().Equal(expected) {
+		t.Error("For TestCropPlainEDF\n",
+			"Expected: ", time.Second, 2, expected,
+			"Got: ", offset, len(c.DataRecords), c.Header.StartTime())
+	}
+	// A start time that does not parse cannot be moved
+	if err = edf.Header.setStartdate(""); err != nil {
+		t.Error("For TestCropPlainEDF\n", err)
+		return
+	}
+	if _, _, err = edf.Crop(time.Second, 2*time.Second); err == nil {
+		t.Error("For TestCropPlainEDF\n", "Expected error for blank startdate")
+	}
+}
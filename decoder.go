@@ -0,0 +1,137 @@
+package biosigio
+
+import (
+	"fmt"
+	"io"
+)
+
+// readHeader reads a complete header record from r
+func readHeader(r io.Reader) (header *Header, err error) {
+	buf := make([]byte, FixedHeaderBytes)
+	if _, err = io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("read fixed header: %v", err)
+	}
+	foffset := fixedHeaderOffsets()
+	ns, err := asciiToInt(buf[FixedHeaderBytes-foffset["numsignal"]:])
+	if err != nil {
+		return nil, fmt.Errorf("serialize ascii to int: %v, for %v", err, buf[FixedHeaderBytes-foffset["numsignal"]:])
+	}
+	if ns < 0 {
+		return nil, fmt.Errorf("number of signals [%v] must not be negative", ns)
+	}
+	buf = append(buf, make([]byte, ns*VariableHeaderBytes)...)
+	if _, err = io.ReadFull(r, buf[FixedHeaderBytes:]); err != nil {
+		return nil, fmt.Errorf("read variable header: %v", err)
+	}
+	header, _, err = unmarshalHeader(buf)
+	if err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// Decoder reads data records one at a time from an input stream
+type Decoder[T Sample] struct {
+	Header    *Header
+	r         io.Reader
+	numsample []int
+	remaining int
+	raw       []byte
+	record    *Record[T]
+	err       error
+}
+
+// EDFDecoder reads EDF data records one at a time from an input stream
+type EDFDecoder = Decoder[int16]
+
+// BDFDecoder reads BDF data records one at a time from an input stream
+type BDFDecoder = Decoder[int32]
+
+// NewDecoder reads the header from r and returns a decoder positioned at the
+// first data record
+func NewDecoder[T Sample](r io.Reader) (d *Decoder[T], err error) {
+	d = &Decoder[T]{r: r}
+	d.Header, err = readHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	d.numsample, err = d.Header.sampleCounts()
+	if err != nil {
+		return nil, err
+	}
+	size, err := d.Header.recordSize(sampleSize[T]())
+	if err != nil {
+		return nil, err
+	}
+	d.raw = make([]byte, size)
+	d.remaining, err = asciiToInt(d.Header.numdatar[:])
+	if err != nil {
+		return nil, fmt.Errorf("serialize ascii to int: %v, for %v", err, d.Header.numdatar)
+	}
+	if d.remaining < UnknownNumDataRecord {
+		return nil, fmt.Errorf("number of data records [%v] must not be negative", d.remaining)
+	}
+	return d, nil
+}
+
+// NewEDFDecoder reads the header from r and returns a decoder positioned at
+// the first data record
+func NewEDFDecoder(r io.Reader) (*EDFDecoder, error) {
+	return NewDecoder[int16](r)
+}
+
+// NewBDFDecoder reads the header from r and returns a decoder positioned at
+// the first data record
+func NewBDFDecoder(r io.Reader) (*BDFDecoder, error) {
+	return NewDecoder[int32](r)
+}
+
+// next reads the raw bytes of the next data record
+func (d *Decoder[T]) next() bool {
+	if d.err != nil || d.remaining == 0 {
+		return false
+	}
+	if _, err := io.ReadFull(d.r, d.raw); err != nil {
+		if (err == io.EOF || err == io.ErrUnexpectedEOF) && d.remaining == UnknownNumDataRecord {
+			// Records of a recording still in progress run until end of
+			// stream, which may cut the last data record short
+			d.remaining = 0
+			return false
+		}
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		d.err = fmt.Errorf("read data record: %v", err)
+		return false
+	}
+	if d.remaining != UnknownNumDataRecord {
+		d.remaining--
+	}
+	return true
+}
+
+// Next advances to the next data record, which is then available through
+// Record. It returns false at the end of the stream or on error.
+func (d *Decoder[T]) Next() bool {
+	if !d.next() {
+		d.record = nil
+		return false
+	}
+	d.record = &Record[T]{}
+	if err := d.record.unmarshalSignals(d.raw, d.numsample); err != nil {
+		d.err = err
+		d.record = nil
+		return false
+	}
+	return true
+}
+
+// Record returns the data record read by the last call to Next
+func (d *Decoder[T]) Record() *Record[T] {
+	return d.record
+}
+
+// Err returns the first error encountered while decoding
+func (d *Decoder[T]) Err() error {
+	return d.err
+}
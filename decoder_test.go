@@ -0,0 +1,151 @@
+package biosigio
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func TestEDFDecoder(t *testing.T) {
+	numsig, numsamp, numrec := 4, 128, 3
+	h, err := NewHeader(Version("0"), NumDataRecord("3"), NumSignal("4"),
+		NumSamples([]string{"128", "128", "128", "128"}))
+	if err != nil {
+		t.Error("For TestEDFDecoder\n", err)
+		return
+	}
+	records := make([]*EDFData, numrec)
+	for idr := range records {
+		signals := make([][]int16, numsig)
+		for idx := range signals {
+			signals[idx] = make([]int16, numsamp)
+			for idy := range signals[idx] {
+				signals[idx][idy] = int16(rand.Intn(65536) - 32768)
+			}
+		}
+		records[idr] = &EDFData{Signals: signals}
+	}
+	buf, err := MarshalEDF(NewEDF(h, records))
+	if err != nil {
+		t.Error("For TestEDFDecoder\n", err)
+		return
+	}
+
+	dec, err := NewEDFDecoder(bytes.NewReader(buf))
+	if err != nil {
+		t.Error("For TestEDFDecoder\n", err)
+		return
+	}
+	if dec.Header.numbytes != h.numbytes {
+		t.Error("For TestEDFDecoder\n",
+			"Expected: ", h.numbytes,
+			"Got: ", dec.Header.numbytes)
+	}
+	var idr int
+	for ; dec.Next(); idr++ {
+		for idx, signal := range dec.Record().Signals {
+			for idy, val := range signal {
+				if val != records[idr].Signals[idx][idy] {
+					t.Error("For TestEDFDecoder\n",
+						"Expected: ", records[idr].Signals[idx][idy],
+						"Got: ", val,
+						"At record: ", idr)
+					return
+				}
+			}
+		}
+	}
+	if dec.Err() != nil {
+		t.Error("For TestEDFDecoder\n", dec.Err())
+	}
+	if idr != numrec {
+		t.Error("For TestEDFDecoder\n",
+			"Expected: ", numrec,
+			"Got: ", idr)
+	}
+
+	dec, err = NewEDFDecoder(bytes.NewReader(buf[:len(buf)-1]))
+	if err != nil {
+		t.Error("For TestEDFDecoder\n", err)
+		return
+	}
+	for dec.Next() {
+	}
+	if dec.Err() == nil {
+		t.Error("For TestEDFDecoder\n", "Expected error for truncated record")
+	}
+}
+
+func TestBDFDecoderFile(t *testing.T) {
+	fn := "./tstdata/testdata.bdf"
+	buf, err := ioutil.ReadFile(fn)
+	if os.IsNotExist(err) {
+		t.Logf("%s\nmissing BDF test data\n", err)
+		return
+	} else if err != nil {
+		t.Errorf("read test data file: %s\n", err)
+	}
+	bdf, err := UnmarshalBDF(buf)
+	if err != nil {
+		t.Errorf("unmarshal test file: %s\n", err)
+		return
+	}
+	dec, err := NewBDFDecoder(bytes.NewReader(buf))
+	if err != nil {
+		t.Errorf("decode test file: %s\n", err)
+		return
+	}
+	var idr int
+	for ; dec.Next(); idr++ {
+		for idx, signal := range dec.Record().Signals {
+			for idy, val := range signal {
+				if val != bdf.DataRecords[idr].Signals[idx][idy] {
+					t.Error("For TestBDFDecoderFile\n",
+						"Expected: ", bdf.DataRecords[idr].Signals[idx][idy],
+						"Got: ", val,
+						"At record: ", idr)
+					return
+				}
+			}
+		}
+	}
+	if dec.Err() != nil {
+		t.Error("For TestBDFDecoderFile\n", dec.Err())
+	}
+	if idr != len(bdf.DataRecords) {
+		t.Error("For TestBDFDecoderFile\n",
+			"Expected: ", len(bdf.DataRecords),
+			"Got: ", idr)
+	}
+}
+
+func TestDecoderMalformedHeader(t *testing.T) {
+	buf := bytes.Repeat([]byte(" "), FixedHeaderBytes)
+	copy(buf[FixedHeaderBytes-4:], "-1")
+	if _, err := NewEDFDecoder(bytes.NewReader(buf)); err == nil {
+		t.Error("For TestDecoderMalformedHeader\n", "Expected error for negative numsignal")
+	}
+	if _, err := NewEDFReader(bytes.NewReader(buf), int64(len(buf))); err == nil {
+		t.Error("For TestDecoderMalformedHeader\n", "Expected error for negative numsignal")
+	}
+
+	h, err := NewHeader(NumDataRecord("1"), NumSignal("1"), NumSamples([]string{"-2"}))
+	if err != nil {
+		t.Error("For TestDecoderMalformedHeader\n", err)
+		return
+	}
+	buf, err = MarshalEDF(NewEDF(h, nil))
+	if err != nil {
+		t.Error("For TestDecoderMalformedHeader\n", err)
+		return
+	}
+	buf = append(buf, make([]byte, 4)...)
+	if _, err = UnmarshalEDF(buf); err == nil {
+		t.Error("For TestDecoderMalformedHeader\n", "Expected error for negative numsample")
+	}
+	if _, err = NewEDFDecoder(bytes.NewReader(buf)); err == nil {
+		t.Error("For TestDecoderMalformedHeader\n", "Expected error for negative numsample")
+	}
+}
@@ -31,13 +31,9 @@ nr of samples[ns] * integer : last signal
 */
 
 import (
-	"bytes"
-	"encoding/binary"
 	"errors"
 	"fmt"
 	"strconv"
-
-	"github.com/kevinjos/eeg-web-server/int24"
 )
 
 var errNotPrintable = errors.New("outside the printable range")
@@ -49,85 +45,115 @@ const (
 	BDFDataByteSize     = 3
 )
 
-var EDFVersion = [8]byte{'\x48', '\x32', '\x32', '\x32', '\x32', '\x32', '\x32', '\x32'}
+// UnknownNumDataRecord is stored as the number of data records while a
+// recording is in progress
+const UnknownNumDataRecord = -1
+
+var EDFVersion = [8]byte{'\x30', '\x20', '\x20', '\x20', '\x20', '\x20', '\x20', '\x20'}
 var BDFVersion = [8]byte{'\xFF', '\x42', '\x49', '\x4F', '\x53', '\x45', '\x4D', '\x49'}
 
-func UnmarshalEDF(buf []byte) (edf *EDF, err error) {
+// Sample is the integer type of the 2-byte samples of EDF and the 3-byte
+// samples of BDF
+type Sample interface {
+	int16 | int32
+}
+
+// sampleSize returns the number of bytes a sample of type T is stored in
+func sampleSize[T Sample]() int {
+	var s T
+	if _, ok := any(s).(int16); ok {
+		return EDFDataByteSize
+	}
+	return BDFDataByteSize
+}
+
+// UnmarshalFile unmarshals byteslice into a file with samples of type T. The
+// version is not checked against T, so files carrying the wrong version still
+// load; File.Validate reports the mismatch.
+func UnmarshalFile[T Sample](buf []byte) (f *File[T], err error) {
 	h, buf, err := unmarshalHeader(buf)
 	if err != nil {
 		return nil, err
 	}
-	numdatar, err := asciiToInt(h.numdatar[:])
+	byteSize := sampleSize[T]()
+	numdatar, err := h.dataRecordCount(len(buf), byteSize)
 	if err != nil {
-		fmt.Errorf("serialize ascii to int: %v, for %v\n", err, h.numdatar)
+		return nil, err
+	}
+	numsample, err := h.sampleCounts()
+	if err != nil {
+		return nil, err
 	}
-	numsignal, err := asciiToInt(h.numsignal[:])
+	size, err := h.recordSize(byteSize)
 	if err != nil {
-		fmt.Errorf("serialize ascii to int: %v, for %v\n", err, h.numsignal)
+		return nil, err
+	}
+	if len(buf) < numdatar*size {
+		return nil, fmt.Errorf("buf has %v bytes, %v data records need %v", len(buf), numdatar, numdatar*size)
+	}
+	if h.NumDataRecords() == UnknownNumDataRecord {
+		// An interrupted recording may end in a partially written data record
+		buf = buf[:numdatar*size]
 	}
-	d := make([]*EDFData, numdatar)
+	d := make([]*Record[T], numdatar)
 	for idx := range d {
-		d[idx] = &EDFData{Signals: make([][]int16, numsignal)}
-		for idy := range d[idx].Signals {
-			numsample, err := asciiToInt(h.numsample[idy][:])
-			if err != nil {
-				fmt.Errorf("serialize ascii to int: %v, for %v\n", err, h.numsample)
-			}
-			d[idx].Signals[idy], err = toInt16(buf[:numsample*EDFDataByteSize])
-			if err != nil {
-				fmt.Errorf("serialize bytes to int failure %v\n", err)
-			}
-			if len(buf) != 0 {
-				buf = buf[numsample*EDFDataByteSize:]
-			}
+		d[idx] = &Record[T]{}
+		if err = d[idx].unmarshalSignals(buf[:size], numsample); err != nil {
+			return nil, err
 		}
+		buf = buf[size:]
 	}
 	if len(buf) != 0 {
 		return nil, fmt.Errorf("buf has %v bytes after unmarshal", len(buf))
 	}
-	edf = NewEDF(h, d)
-	return edf, nil
+	f = NewFile(h, d)
+	return f, nil
 }
 
+// UnmarshalEDF unmarshals byteslice into edf
+func UnmarshalEDF(buf []byte) (edf *EDF, err error) {
+	return UnmarshalFile[int16](buf)
+}
+
+// UnmarshalBDF unmarshals byteslice into bdf
 func UnmarshalBDF(buf []byte) (bdf *BDF, err error) {
-	h, buf, err := unmarshalHeader(buf)
+	return UnmarshalFile[int32](buf)
+}
+
+// dataRecordCount parses the number of data records, deriving it from the n
+// bytes following the header while a recording is in progress
+func (h *Header) dataRecordCount(n int, byteSize int) (numdatar int, err error) {
+	numdatar, err = asciiToInt(h.numdatar[:])
 	if err != nil {
-		return nil, err
+		return 0, fmt.Errorf("serialize ascii to int: %v, for %v", err, h.numdatar)
 	}
-	numdatar, err := asciiToInt(h.numdatar[:])
-	if err != nil {
-		fmt.Errorf("serialize ascii to int: %v, for %v\n", err, h.numdatar)
+	if numdatar == UnknownNumDataRecord {
+		return countDataRecords(h, n, byteSize)
 	}
-	numsignal, err := asciiToInt(h.numsignal[:])
-	if err != nil {
-		fmt.Errorf("serialize ascii to int: %v, for %v\n", err, h.numsignal)
+	if numdatar < 0 {
+		return 0, fmt.Errorf("number of data records [%v] must not be negative", numdatar)
 	}
-	d := make([]*BDFData, numdatar)
-	for idx := range d {
-		d[idx] = &BDFData{Signals: make([][]int32, numsignal)}
-		for idy := range d[idx].Signals {
-			numsample, err := asciiToInt(h.numsample[idy][:])
-			if err != nil {
-				fmt.Errorf("serialize ascii to int: %v, for %v\n", err, h.numsample)
-			}
-			d[idx].Signals[idy] = toInt32(buf[:numsample*BDFDataByteSize])
-			if err != nil {
-				fmt.Errorf("serialize bytes to int failure %v\n", err)
-			}
-			if len(buf) != 0 {
-				buf = buf[numsample*BDFDataByteSize:]
-			}
-		}
+	return numdatar, nil
+}
+
+// countDataRecords derives the number of data records from the number of
+// bytes following the header, ignoring a trailing partial data record
+func countDataRecords(h *Header, n int, byteSize int) (int, error) {
+	size, err := h.recordSize(byteSize)
+	if err != nil {
+		return 0, err
 	}
-	if len(buf) != 0 {
-		return nil, fmt.Errorf("buf has %v bytes after unmarshal", len(buf))
+	if size == 0 {
+		return 0, nil
 	}
-	bdf = NewBDF(h, d)
-	return bdf, nil
+	return n / size, nil
 }
 
 // Unmarshal byteslice into edf
 func unmarshalHeader(buf []byte) (header *Header, trimedbuf []byte, err error) {
+	if len(buf) < FixedHeaderBytes {
+		return nil, buf, fmt.Errorf("buf has %v bytes, fixed header needs %v", len(buf), FixedHeaderBytes)
+	}
 	foffset := fixedHeaderOffsets()
 	fixed := func(os int) (x, res []byte) {
 		return buf[:os], buf[os:]
@@ -146,12 +172,21 @@ func unmarshalHeader(buf []byte) (header *Header, trimedbuf []byte, err error) {
 
 	ns, err := asciiToInt(numsignal)
 	if err != nil {
-		fmt.Errorf("serialize ascii to int failure %v, for %v\n", err, numsignal)
+		return nil, buf, fmt.Errorf("serialize ascii to int failure %v, for %v", err, numsignal)
+	}
+	if ns < 0 {
+		return nil, buf, fmt.Errorf("number of signals [%v] must not be negative", ns)
+	}
+	if len(buf) < ns*VariableHeaderBytes {
+		return nil, buf, fmt.Errorf("buf has %v bytes, variable header needs %v", len(buf), ns*VariableHeaderBytes)
 	}
 
 	voffset := variableHeaderOffsets(numsignal)
 	variable := func(os int) (x []string, res []byte) {
 		x = make([]string, ns)
+		if ns == 0 {
+			return x, buf
+		}
 		osps := os / ns
 		for idx, _ := range x {
 			x[idx] = string(buf[:osps])
@@ -199,18 +234,15 @@ func unmarshalHeader(buf []byte) (header *Header, trimedbuf []byte, err error) {
 	return h, buf, nil
 }
 
-// Marshal edf into byte slice
-func MarshalEDF(edf *EDF) (buf []byte, err error) {
-	buf, err = edf.Header.appendContents(buf)
+// MarshalFile marshals a file into byte slice
+func MarshalFile[T Sample](f *File[T]) (buf []byte, err error) {
+	buf, err = f.Header.appendContents(buf)
 	if err != nil {
 		return nil, err
 	}
-	for _, dataRecord := range edf.DataRecords {
+	for _, dataRecord := range f.DataRecords {
 		if dataRecord.rawData == nil {
 			dataRecord.marshalSignals()
-			if err != nil {
-				return buf, err
-			}
 		}
 		buf = append(buf, dataRecord.rawData...)
 	}
@@ -218,105 +250,84 @@ func MarshalEDF(edf *EDF) (buf []byte, err error) {
 }
 
 // Marshal edf into byte slice
-func MarshalBDF(edf *BDF) (buf []byte, err error) {
-	buf, err = edf.Header.appendContents(buf)
-	if err != nil {
-		return nil, err
-	}
-	for _, dataRecord := range edf.DataRecords {
-		if dataRecord.rawData == nil {
-			dataRecord.marshalSignals()
-			if err != nil {
-				return buf, err
-			}
-		}
-		buf = append(buf, dataRecord.rawData...)
-	}
-	return buf, nil
+func MarshalEDF(edf *EDF) (buf []byte, err error) {
+	return MarshalFile(edf)
 }
 
-func NewEDF(h *Header, d []*EDFData) *EDF {
-	if h.version != EDFVersion {
-		fmt.Errorf("Version string error %s != %s", h.version, EDFVersion)
-	}
-	ndr, _ := asciiToInt(h.numdatar[:])
-	if ndr != len(d) {
-		fmt.Errorf("number of data records [%v] must equal length of []*Data [%v]\n",
-			ndr, len(d))
-	}
-	ns, _ := asciiToInt(h.numsignal[:])
-	for _, record := range d {
-		if record.Signals != nil && ns != len(record.Signals) {
-			fmt.Errorf("number of samples [%v] must equal length of Signals array [%v]\n",
-				ns, len(record.Signals))
-		}
-	}
-	return &EDF{
-		Header:      h,
-		DataRecords: d,
-	}
+// Marshal bdf into byte slice
+func MarshalBDF(bdf *BDF) (buf []byte, err error) {
+	return MarshalFile(bdf)
 }
 
-func NewBDF(h *Header, d []*BDFData) *BDF {
-	if h.version != BDFVersion {
-		fmt.Errorf("Version string error %s != %s", h.version, BDFVersion)
-	}
-	ndr, _ := asciiToInt(h.numdatar[:])
-	if ndr != len(d) {
-		fmt.Errorf("number of data records [%v] must equal length of []*Data [%v]\n",
-			ndr, len(d))
-	}
-	ns, _ := asciiToInt(h.numsignal[:])
-	for _, record := range d {
-		if record.Signals != nil && ns != len(record.Signals) {
-			fmt.Errorf("number of samples [%v] must equal length of Signals array [%v]\n",
-				ns, len(record.Signals))
-		}
-	}
-	return &BDF{
+// NewFile assembles a header and its data records. Use Validate to check
+// that they are consistent.
+func NewFile[T Sample](h *Header, d []*Record[T]) *File[T] {
+	return &File[T]{
 		Header:      h,
 		DataRecords: d,
 	}
 }
 
-type EDF struct {
-	Header      *Header
-	DataRecords []*EDFData
+// NewEDF assembles a header and its data records. Use Validate to check
+// that they are consistent.
+func NewEDF(h *Header, d []*EDFData) *EDF {
+	return NewFile(h, d)
 }
 
-type BDF struct {
-	Header      *Header
-	DataRecords []*BDFData
+// NewBDF assembles a header and its data records. Use Validate to check
+// that they are consistent.
+func NewBDF(h *Header, d []*BDFData) *BDF {
+	return NewFile(h, d)
 }
 
-// EDFData holds one edf data record
-type EDFData struct {
-	rawData []byte
-	Signals [][]int16
+// File holds the header and the data records of an EDF or BDF file
+type File[T Sample] struct {
+	Header      *Header
+	DataRecords []*Record[T]
 }
 
-// BDFData holds one bdf data record
-type BDFData struct {
+// Record holds one data record
+type Record[T Sample] struct {
 	rawData []byte
-	Signals [][]int32
+	Signals [][]T
 }
 
-func (d *EDFData) marshalSignals() {
-	for _, signal := range d.Signals {
-		for _, numval := range signal {
-			buf := new(bytes.Buffer)
-			_ = binary.Write(buf, binary.LittleEndian, int16(numval))
-			d.rawData = append(d.rawData, buf.Bytes()...)
+// EDF holds an edf file with 2-byte samples
+type EDF = File[int16]
+
+// BDF holds a bdf file with 3-byte samples
+type BDF = File[int32]
+
+// EDFData holds one edf data record
+type EDFData = Record[int16]
+
+// BDFData holds one bdf data record
+type BDFData = Record[int32]
+
+// unmarshalSignals splits one raw data record into its signals
+func (d *Record[T]) unmarshalSignals(buf []byte, numsample []int) (err error) {
+	byteSize := sampleSize[T]()
+	d.Signals = make([][]T, len(numsample))
+	for idx, ns := range numsample {
+		d.Signals[idx], err = toSamples[T](buf[:ns*byteSize])
+		if err != nil {
+			return fmt.Errorf("serialize bytes to int failure %v", err)
 		}
+		buf = buf[ns*byteSize:]
 	}
+	return nil
+}
+
+func (d *Record[T]) marshalSignals() {
+	d.rawData = d.appendSignals(d.rawData)
 }
 
-func (d *BDFData) marshalSignals() {
+// appendSignals appends the little-endian encoding of the signals
+func (d *Record[T]) appendSignals(buf []byte) []byte {
 	for _, signal := range d.Signals {
-		for _, numval := range signal {
-			d.rawData = append(d.rawData, int24.MarshalSLE(numval)...)
-		}
+		buf = appendSamples(buf, signal)
 	}
+	return buf
 }
 
 // NewHeader instantiates a edf header
@@ -840,6 +851,128 @@ func (h *Header) appendContents(contents []byte) (buf []byte, err error) {
 	return buf, nil
 }
 
+// dataByteSize returns the sample size given by the version: 3 bytes for BDF
+// and 2 bytes for EDF
+func (h *Header) dataByteSize() int {
+	if h.version == BDFVersion {
+		return BDFDataByteSize
+	}
+	return EDFDataByteSize
+}
+
+// sampleCounts parses the number of samples in each data record per signal
+func (h *Header) sampleCounts() (numsample []int, err error) {
+	numsample = make([]int, len(h.numsample))
+	for idx, val := range h.numsample {
+		numsample[idx], err = asciiToInt(val[:])
+		if err != nil {
+			return nil, fmt.Errorf("serialize ascii to int: %v, for %v", err, val)
+		}
+		if numsample[idx] < 0 {
+			return nil, fmt.Errorf("signal %v number of samples [%v] must not be negative", idx, numsample[idx])
+		}
+	}
+	return numsample, nil
+}
+
+// recordSize returns the number of bytes in one data record for samples of
+// byteSize bytes
+func (h *Header) recordSize(byteSize int) (size int, err error) {
+	numsample, err := h.sampleCounts()
+	if err != nil {
+		return 0, err
+	}
+	for _, val := range numsample {
+		size += val * byteSize
+	}
+	return size, nil
+}
+
+// signalHeader holds the per-signal header fields of one signal
+type signalHeader struct {
+	label          [16]byte
+	transducerType [80]byte
+	phydim         [8]byte
+	phymin         [8]byte
+	phymax         [8]byte
+	digmin         [8]byte
+	digmax         [8]byte
+	prefilter      [80]byte
+	numsample      [8]byte
+	nsreserved     [32]byte
+}
+
+// signalHeaders collects the per-signal header fields by signal
+func (h *Header) signalHeaders() []signalHeader {
+	sigs := make([]signalHeader, len(h.label))
+	for idx := range sigs {
+		sigs[idx] = signalHeader{
+			label:          h.label[idx],
+			transducerType: h.transducerType[idx],
+			phydim:         h.phydim[idx],
+			phymin:         h.phymin[idx],
+			phymax:         h.phymax[idx],
+			digmin:         h.digmin[idx],
+			digmax:         h.digmax[idx],
+			prefilter:      h.prefilter[idx],
+			numsample:      h.numsample[idx],
+			nsreserved:     h.nsreserved[idx],
+		}
+	}
+	return sigs
+}
+
+// withSignals returns a copy of the header holding the given signals, with
+// the number of signals and the number of bytes in the header updated
+func (h *Header) withSignals(sigs []signalHeader) (*Header, error) {
+	c := &Header{}
+	*c = *h
+	if err := c.setNumSig(strconv.Itoa(len(sigs))); err != nil {
+		return nil, err
+	}
+	c.label = make([][16]byte, len(sigs))
+	c.transducerType = make([][80]byte, len(sigs))
+	c.phydim = make([][8]byte, len(sigs))
+	c.phymin = make([][8]byte, len(sigs))
+	c.phymax = make([][8]byte, len(sigs))
+	c.digmin = make([][8]byte, len(sigs))
+	c.digmax = make([][8]byte, len(sigs))
+	c.prefilter = make([][80]byte, len(sigs))
+	c.numsample = make([][8]byte, len(sigs))
+	c.nsreserved = make([][32]byte, len(sigs))
+	for idx, sig := range sigs {
+		c.label[idx] = sig.label
+		c.transducerType[idx] = sig.transducerType
+		c.phydim[idx] = sig.phydim
+		c.phymin[idx] = sig.phymin
+		c.phymax[idx] = sig.phymax
+		c.digmin[idx] = sig.digmin
+		c.digmax[idx] = sig.digmax
+		c.prefilter[idx] = sig.prefilter
+		c.numsample[idx] = sig.numsample
+		c.nsreserved[idx] = sig.nsreserved
+	}
+	if err := c.setNumBytes(strconv.Itoa(c.calcNumBytes(len(sigs)))); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// setField copies printable ascii into a header field padded with spaces
+func setField(field []byte, val string) error {
+	if len(val) > len(field) {
+		return fmt.Errorf("%q is longer than %v bytes", val, len(field))
+	}
+	for idx, c := range []byte(val) {
+		if c < 32 || c > 126 {
+			return fmt.Errorf("%s for %v in setField", errNotPrintable, c)
+		}
+		field[idx] = c
+	}
+	fillWithSpaces(field[len(val):])
+	return nil
+}
+
 func (h *Header) calcNumBytes(ns int) (nb int) {
 	nb += len(h.version)
 	nb += len(h.LPID)
@@ -166,7 +166,7 @@ func TestUnmarshalEDF(t *testing.T) {
 		Prefilters(strArr), NumSamples(strArrNsamp), NSReserved(strArr))
 
 	if err != nil {
-		t.Error("For TestUnmarshal %s\n", err)
+		t.Errorf("For TestUnmarshal %s\n", err)
 		return
 	}
 
@@ -304,3 +304,58 @@ func TestUnmarshalBDFFile(t *testing.T) {
 			"Got: ", drByteCount)
 	}
 }
+
+func TestUnmarshalFile(t *testing.T) {
+	h, err := NewHeader(Version(string(BDFVersion[:])), NumDataRecord("2"),
+		NumSignal("2"), NumSamples([]string{"3", "1"}))
+	if err != nil {
+		t.Error("For TestUnmarshalFile\n", err)
+		return
+	}
+	bdf := NewFile(h, []*Record[int32]{
+		{Signals: [][]int32{{-8388608, 0, 8388607}, {-1}}},
+		{Signals: [][]int32{{1, 2, 3}, {4}}},
+	})
+	buf, err := MarshalFile(bdf)
+	if err != nil {
+		t.Error("For TestUnmarshalFile\n", err)
+		return
+	}
+	if len(buf) != h.NumBytes()+2*4*BDFDataByteSize {
+		t.Error("For TestUnmarshalFile\n",
+			"Expected: ", h.NumBytes()+2*4*BDFDataByteSize,
+			"Got: ", len(buf))
+	}
+	newBDF, err := UnmarshalFile[int32](buf)
+	if err != nil {
+		t.Error("For TestUnmarshalFile\n", err)
+		return
+	}
+	for idr, record := range newBDF.DataRecords {
+		for idx, signal := range record.Signals {
+			for idy, val := range signal {
+				if val != bdf.DataRecords[idr].Signals[idx][idy] {
+					t.Error("For TestUnmarshalFile\n",
+						"Expected: ", bdf.DataRecords[idr].Signals[idx][idy],
+						"Got: ", val)
+				}
+			}
+		}
+	}
+	if h.dataByteSize() != BDFDataByteSize {
+		t.Error("For TestUnmarshalFile\n",
+			"Expected: ", BDFDataByteSize,
+			"Got: ", h.dataByteSize())
+	}
+	// The version of the header decides the sample size
+	edf := NewFile(h, []*Record[int16]{})
+	var found bool
+	for _, e := range edf.Validate() {
+		if e.Field == "version" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("For TestUnmarshalFile\n", "Expected version error for EDF samples in BDF")
+	}
+}
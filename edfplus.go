@@ -0,0 +1,227 @@
+package biosigio
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Reserved field values of EDF+ files with contiguous and discontinuous
+// data records
+const (
+	EDFPlusContinuous    = "EDF+C"
+	EDFPlusDiscontinuous = "EDF+D"
+)
+
+// Reserved field values of BDF+ files with contiguous and discontinuous
+// data records
+const (
+	BDFPlusContinuous    = "BDF+C"
+	BDFPlusDiscontinuous = "BDF+D"
+)
+
+// EDFPlus reports whether the reserved field marks the file as EDF+, or as
+// its 3-byte counterpart BDF+
+func (h *Header) EDFPlus() bool {
+	res := string(h.reserved[:])
+	for _, plus := range []string{EDFPlusContinuous, EDFPlusDiscontinuous, BDFPlusContinuous, BDFPlusDiscontinuous} {
+		if strings.HasPrefix(res, plus) {
+			return true
+		}
+	}
+	return false
+}
+
+// Discontinuous reports whether the reserved field marks the data records as
+// not contiguous in time, in which case each data record's start is given by
+// its time-keeping annotation
+func (h *Header) Discontinuous() bool {
+	res := string(h.reserved[:])
+	return strings.HasPrefix(res, EDFPlusDiscontinuous) || strings.HasPrefix(res, BDFPlusDiscontinuous)
+}
+
+// plusReserved returns the reserved field of an EDF+ or BDF+ file of
+// byteSize samples
+func plusReserved(byteSize int, discontinuous bool) string {
+	switch {
+	case byteSize == BDFDataByteSize && discontinuous:
+		return BDFPlusDiscontinuous
+	case byteSize == BDFDataByteSize:
+		return BDFPlusContinuous
+	case discontinuous:
+		return EDFPlusDiscontinuous
+	}
+	return EDFPlusContinuous
+}
+
+// parseDotted parses fields of the form nn.nn.nn such as dd.mm.yy or hh.mm.ss
+func parseDotted(field []byte) (vals [3]int, err error) {
+	s := string(field)
+	if len(s) != 8 || s[2] != '.' || s[5] != '.' {
+		return vals, fmt.Errorf("%q is not of the form nn.nn.nn", s)
+	}
+	for idx := range vals {
+		part := s[idx*3 : idx*3+2]
+		if part[0] < '0' || part[0] > '9' || part[1] < '0' || part[1] > '9' {
+			return vals, fmt.Errorf("%q is not of the form nn.nn.nn", s)
+		}
+		vals[idx] = int(part[0]-'0')*10 + int(part[1]-'0')
+	}
+	return vals, nil
+}
+
+// parseStartdate parses a startdate of the form dd.mm.yy. Two digit years
+// from 85 to 99 fall in 1985-1999, others in 2000-2084.
+func parseStartdate(field []byte) (t time.Time, err error) {
+	date, err := parseDotted(field)
+	if err != nil {
+		return t, err
+	}
+	year := 2000 + date[2]
+	if date[2] >= 85 {
+		year = 1900 + date[2]
+	}
+	t = time.Date(year, time.Month(date[1]), date[0], 0, 0, 0, 0, time.UTC)
+	if t.Day() != date[0] || int(t.Month()) != date[1] {
+		return time.Time{}, fmt.Errorf("%q is not a valid date", field)
+	}
+	return t, nil
+}
+
+// parseStarttime parses a starttime of the form hh.mm.ss
+func parseStarttime(field []byte) (d time.Duration, err error) {
+	clock, err := parseDotted(field)
+	if err != nil {
+		return 0, err
+	}
+	if clock[0] > 23 || clock[1] > 59 || clock[2] > 59 {
+		return 0, fmt.Errorf("%q is not a valid time", field)
+	}
+	return time.Duration(clock[0])*time.Hour + time.Duration(clock[1])*time.Minute +
+		time.Duration(clock[2])*time.Second, nil
+}
+
+// startDate parses the startdate field. The year is taken from the
+// Startdate subfield of an EDF+ local recording identification when it
+// agrees with the two-digit year. EDF+ writes the year as yy after 2084,
+// leaving it to that subfield, which must then agree on the day and month.
+func (h *Header) startDate() (time.Time, error) {
+	lrid, ok := h.recordingDate()
+	if s := string(h.startdate[:]); strings.HasSuffix(s, ".yy") {
+		if !ok {
+			return time.Time{}, fmt.Errorf("%q needs a Startdate subfield in the local recording identification", s)
+		}
+		if s != lrid.Format("02.01.")+"yy" {
+			return time.Time{}, fmt.Errorf("%q disagrees with the recording Startdate %v", s, formatEDFDate(lrid))
+		}
+		return lrid, nil
+	}
+	date, err := parseStartdate(h.startdate[:])
+	if err != nil {
+		return time.Time{}, err
+	}
+	if ok && lrid.Year()%100 == date.Year()%100 {
+		full := time.Date(lrid.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		if full.Day() == date.Day() {
+			date = full
+		}
+	}
+	return date, nil
+}
+
+// startTime combines the startdate and starttime fields. EDF holds no time
+// zone, so the clock time is returned in UTC.
+func (h *Header) startTime() (time.Time, error) {
+	date, err := h.startDate()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("startdate: %v", err)
+	}
+	clock, err := parseStarttime(h.starttime[:])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("starttime: %v", err)
+	}
+	return date.Add(clock), nil
+}
+
+// setStartTime writes t to the startdate and starttime fields, dropping
+// fractions of a second. The Startdate subfield of an EDF+ local recording
+// identification is updated too, allowing years outside 1985-2084. Years
+// after 2084 are written as yy in the startdate.
+func (h *Header) setStartTime(t time.Time) error {
+	lrid := strings.Fields(trimField(h.LRID[:]))
+	if len(lrid) >= 2 && lrid[0] == recordingStartdate {
+		lrid[1] = formatEDFDate(t)
+		s := strings.Join(lrid, " ")
+		if err := checkIdentification(s); err != nil {
+			return err
+		}
+		if err := h.setLRID(s); err != nil {
+			return err
+		}
+	} else if t.Year() < 1985 || t.Year() > 2084 {
+		return fmt.Errorf("year %v outside 1985-2084", t.Year())
+	}
+	startdate := t.Format("02.01.06")
+	if t.Year() > 2084 {
+		startdate = t.Format("02.01.") + "yy"
+	}
+	if err := h.setStartdate(startdate); err != nil {
+		return err
+	}
+	return h.setStarttime(t.Format("15.04.05"))
+}
+
+// RecordTime returns the absolute start time of data record idx
+func (f *File[T]) RecordTime(idx int) (time.Time, error) {
+	start, err := f.Header.startTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	offset, err := f.RecordStart(idx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return start.Add(offset), nil
+}
+
+// Segment is a run of data records [First, Last) contiguous in time. Start is
+// measured from the start of the recording.
+type Segment struct {
+	Start    time.Duration
+	Duration time.Duration
+	First    int
+	Last     int
+}
+
+// Segments splits the data records into runs contiguous in time. Files that
+// are not EDF+D hold a single segment.
+func (f *File[T]) Segments() (segs []Segment, err error) {
+	duration, err := asciiToDuration(f.Header.duration[:])
+	if err != nil {
+		return nil, fmt.Errorf("serialize ascii to duration: %v, for %v", err, f.Header.duration)
+	}
+	if len(f.DataRecords) == 0 {
+		return nil, nil
+	}
+	if !f.Header.Discontinuous() {
+		start, err := f.RecordStart(0)
+		if err != nil {
+			return nil, err
+		}
+		n := len(f.DataRecords)
+		return []Segment{{Start: start, Duration: time.Duration(n) * duration, First: 0, Last: n}}, nil
+	}
+	for idx := range f.DataRecords {
+		start, err := f.RecordStart(idx)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(segs); n > 0 && segs[n-1].Start+segs[n-1].Duration == start {
+			segs[n-1].Duration += duration
+			segs[n-1].Last = idx + 1
+			continue
+		}
+		segs = append(segs, Segment{Start: start, Duration: duration, First: idx, Last: idx + 1})
+	}
+	return segs, nil
+}
@@ -0,0 +1,72 @@
+package biosigio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEDFPlusDiscontinuous(t *testing.T) {
+	h, err := NewHeader(Version("0"), Startdate("12.07.15"), Starttime("21.18.32"),
+		Reserved(EDFPlusDiscontinuous), NumDataRecord("4"), Duration("1"),
+		NumSignal("2"), Labels([]string{"ECG", AnnotationsLabel}),
+		NumSamples([]string{"2", "8"}))
+	if err != nil {
+		t.Error("For TestEDFPlusDiscontinuous\n", err)
+		return
+	}
+	starts := []string{"+0", "+1", "+5.5", "+6.5"}
+	records := make([]*EDFData, len(starts))
+	for idr, start := range starts {
+		records[idr] = &EDFData{Signals: [][]int16{{0, 0}, talSamples(start+"\x14\x14\x00", 8)}}
+	}
+	edf := NewEDF(h, records)
+	if !h.EDFPlus() || !h.Discontinuous() {
+		t.Error("For TestEDFPlusDiscontinuous\n", "Expected EDF+D header")
+	}
+	rt, err := edf.RecordTime(2)
+	expected := time.Date(2015, time.July, 12, 21, 18, 37, 5e8, time.UTC)
+	if err != nil || !rt.Equal(expected) {
+		t.Error("For TestEDFPlusDiscontinuous\n",
+			"Expected: ", expected,
+			"Got: ", rt, err)
+	}
+	segs, err := edf.Segments()
+	if err != nil {
+		t.Error("For TestEDFPlusDiscontinuous\n", err)
+		return
+	}
+	expectedSegs := []Segment{
+		{Start: 0, Duration: 2 * time.Second, First: 0, Last: 2},
+		{Start: 5500 * time.Millisecond, Duration: 2 * time.Second, First: 2, Last: 4},
+	}
+	if len(segs) != len(expectedSegs) || segs[0] != expectedSegs[0] || segs[1] != expectedSegs[1] {
+		t.Error("For TestEDFPlusDiscontinuous\n",
+			"Expected: ", expectedSegs,
+			"Got: ", segs)
+	}
+}
+
+func TestHeaderStartTime(t *testing.T) {
+	for _, tc := range []struct {
+		date, clock string
+		expected    time.Time
+	}{
+		{"31.12.99", "23.59.59", time.Date(1999, time.December, 31, 23, 59, 59, 0, time.UTC)},
+		{"01.01.84", "00.00.00", time.Date(2084, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"01.01.85", "00.00.00", time.Date(1985, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		h, _ := NewHeader(Startdate(tc.date), Starttime(tc.clock), NumSignal("0"))
+		st, err := h.startTime()
+		if err != nil || !st.Equal(tc.expected) {
+			t.Error("For TestHeaderStartTime\n",
+				"Expected: ", tc.expected,
+				"Got: ", st, err)
+		}
+	}
+	for _, date := range []string{"31.02.15", "1.1.15", "12-07-15"} {
+		h, _ := NewHeader(Startdate(date), Starttime("00.00.00"), NumSignal("0"))
+		if _, err := h.startTime(); err == nil {
+			t.Errorf("For TestHeaderStartTime\nExpected error for %q", date)
+		}
+	}
+}
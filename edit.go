@@ -0,0 +1,67 @@
+package biosigio
+
+import (
+	"fmt"
+	"io"
+)
+
+// sameLayout checks that data records described by h and o hold the same
+// number of bytes per signal and that the headers are the same length
+func (h *Header) sameLayout(o *Header) error {
+	if h.numbytes != o.numbytes {
+		return fmt.Errorf("number of bytes in header changed from %q to %q", h.numbytes[:], o.numbytes[:])
+	}
+	if h.numsignal != o.numsignal || len(h.numsample) != len(o.numsample) {
+		return fmt.Errorf("number of signals changed from %q to %q", h.numsignal[:], o.numsignal[:])
+	}
+	if h.dataByteSize() != o.dataByteSize() {
+		return fmt.Errorf("sample size changed from %v to %v bytes", h.dataByteSize(), o.dataByteSize())
+	}
+	for idx := range h.numsample {
+		if h.numsample[idx] != o.numsample[idx] {
+			return fmt.Errorf("signal %v number of samples changed from %q to %q",
+				idx, h.numsample[idx][:], o.numsample[idx][:])
+		}
+	}
+	return nil
+}
+
+// EditHeader applies edits, such as the header setters, to the header at the
+// start of rws and writes it back in place, leaving the data records
+// untouched. Edits changing the length of the header or the layout of the
+// data records are refused and nothing is written.
+func EditHeader(rws io.ReadWriteSeeker, edits ...func(*Header) error) error {
+	if _, err := rws.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek to header: %v", err)
+	}
+	h, err := readHeader(rws)
+	if err != nil {
+		return err
+	}
+	edited, err := h.withSignals(h.signalHeaders())
+	if err != nil {
+		return err
+	}
+	for _, edit := range edits {
+		if err = edit(edited); err != nil {
+			return err
+		}
+	}
+	if err = h.sameLayout(edited); err != nil {
+		return err
+	}
+	buf, err := edited.appendContents(nil)
+	if err != nil {
+		return err
+	}
+	if len(buf) != h.NumBytes() {
+		return fmt.Errorf("edited header is %v bytes, want %v", len(buf), h.NumBytes())
+	}
+	if _, err = rws.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("seek to header: %v", err)
+	}
+	if _, err = rws.Write(buf); err != nil {
+		return fmt.Errorf("write header: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package biosigio
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestEditHeader(t *testing.T) {
+	h, err := NewHeader(Version("0"), NumDataRecord("2"), Duration("1"), NumSignal("2"),
+		Labels([]string{"EEG Fzp-Cz", "ECG"}), NumSamples([]string{"3", "1"}))
+	if err != nil {
+		t.Error("For TestEditHeader\n", err)
+		return
+	}
+	buf, err := MarshalEDF(NewEDF(h, []*EDFData{
+		{Signals: [][]int16{{1, 2, 3}, {4}}},
+		{Signals: [][]int16{{5, 6, 7}, {8}}},
+	}))
+	if err != nil {
+		t.Error("For TestEditHeader\n", err)
+		return
+	}
+	f, err := ioutil.TempFile("", "biosigio")
+	if err != nil {
+		t.Error("For TestEditHeader\n", err)
+		return
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if _, err = f.Write(buf); err != nil {
+		t.Error("For TestEditHeader\n", err)
+		return
+	}
+
+	err = EditHeader(f, Labels([]string{"EEG Fpz-Cz", "ECG"}), LocalPatientID("X X X X"))
+	if err != nil {
+		t.Error("For TestEditHeader\n", err)
+		return
+	}
+	edited, err := ioutil.ReadFile(f.Name())
+	if err != nil {
+		t.Error("For TestEditHeader\n", err)
+		return
+	}
+	if len(edited) != len(buf) || string(edited[h.NumBytes():]) != string(buf[h.NumBytes():]) {
+		t.Error("For TestEditHeader\n",
+			"Expected: ", buf[h.NumBytes():],
+			"Got: ", edited[h.NumBytes():])
+	}
+	edf, err := UnmarshalEDF(edited)
+	if err != nil {
+		t.Error("For TestEditHeader\n", err)
+		return
+	}
+	if labels := edf.Header.Labels(); labels[0] != "EEG Fpz-Cz" || edf.Header.LocalPatientID() != "X X X X" {
+		t.Error("For TestEditHeader\n",
+			"Expected: ", "EEG Fpz-Cz", "X X X X",
+			"Got: ", labels[0], edf.Header.LocalPatientID())
+	}
+
+	for _, edit := range []func(*Header) error{
+		NumSamples([]string{"3", "2"}),
+		Version(string(BDFVersion[:])),
+		NumBytes("1024"),
+	} {
+		if err = EditHeader(f, edit); err == nil {
+			t.Error("For TestEditHeader\n", "Expected error for layout change")
+		}
+	}
+	unchanged, err := ioutil.ReadFile(f.Name())
+	if err != nil || string(unchanged) != string(edited) {
+		t.Error("For TestEditHeader\n", "Expected refused edits to leave the file unchanged", err)
+	}
+}
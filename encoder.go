@@ -0,0 +1,71 @@
+package biosigio
+
+import (
+	"fmt"
+	"io"
+)
+
+// Encoder writes data records one at a time to an output stream
+type Encoder[T Sample] struct {
+	Header    *Header
+	w         io.Writer
+	numsample []int
+	count     int
+	buf       []byte
+}
+
+// EDFEncoder writes EDF data records one at a time to an output stream
+type EDFEncoder = Encoder[int16]
+
+// BDFEncoder writes BDF data records one at a time to an output stream
+type BDFEncoder = Encoder[int32]
+
+// NewEncoder writes the header h to w and returns an encoder ready to accept
+// data records
+func NewEncoder[T Sample](w io.Writer, h *Header) (e *Encoder[T], err error) {
+	e = &Encoder[T]{w: w, Header: h}
+	e.numsample, err = h.sampleCounts()
+	if err != nil {
+		return nil, err
+	}
+	buf, err := h.appendContents(nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(buf); err != nil {
+		return nil, fmt.Errorf("write header: %v", err)
+	}
+	return e, nil
+}
+
+// NewEDFEncoder writes the header h to w and returns an encoder ready to
+// accept data records
+func NewEDFEncoder(w io.Writer, h *Header) (*EDFEncoder, error) {
+	return NewEncoder[int16](w, h)
+}
+
+// NewBDFEncoder writes the header h to w and returns an encoder ready to
+// accept data records
+func NewBDFEncoder(w io.Writer, h *Header) (*BDFEncoder, error) {
+	return NewEncoder[int32](w, h)
+}
+
+// Encode writes one data record to the output stream
+func (e *Encoder[T]) Encode(d *Record[T]) error {
+	if len(d.Signals) != len(e.numsample) {
+		return fmt.Errorf("number of signals [%v] must equal length of Signals array [%v]",
+			len(e.numsample), len(d.Signals))
+	}
+	for idx, signal := range d.Signals {
+		if len(signal) != e.numsample[idx] {
+			return fmt.Errorf("number of samples [%v] must equal length of signal %v [%v]",
+				e.numsample[idx], idx, len(signal))
+		}
+	}
+	e.buf = d.appendSignals(e.buf[:0])
+	if _, err := e.w.Write(e.buf); err != nil {
+		return fmt.Errorf("write data record: %v", err)
+	}
+	e.count++
+	return nil
+}
@@ -0,0 +1,91 @@
+package biosigio
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func TestEDFEncoder(t *testing.T) {
+	numsig, numsamp, numrec := 4, 128, 3
+	h, err := NewHeader(Version("0"), NumDataRecord("3"), NumSignal("4"),
+		NumSamples([]string{"128", "128", "128", "128"}))
+	if err != nil {
+		t.Error("For TestEDFEncoder\n", err)
+		return
+	}
+	records := make([]*EDFData, numrec)
+	for idr := range records {
+		signals := make([][]int16, numsig)
+		for idx := range signals {
+			signals[idx] = make([]int16, numsamp)
+			for idy := range signals[idx] {
+				signals[idx][idy] = int16(rand.Intn(65536) - 32768)
+			}
+		}
+		records[idr] = &EDFData{Signals: signals}
+	}
+
+	var out bytes.Buffer
+	enc, err := NewEDFEncoder(&out, h)
+	if err != nil {
+		t.Error("For TestEDFEncoder\n", err)
+		return
+	}
+	for _, record := range records {
+		if err = enc.Encode(record); err != nil {
+			t.Error("For TestEDFEncoder\n", err)
+			return
+		}
+	}
+	buf, err := MarshalEDF(NewEDF(h, records))
+	if err != nil {
+		t.Error("For TestEDFEncoder\n", err)
+		return
+	}
+	if !bytes.Equal(out.Bytes(), buf) {
+		t.Error("For TestEDFEncoder\n",
+			"Expected: ", len(buf), " bytes matching MarshalEDF",
+			"Got: ", out.Len())
+	}
+
+	short := &EDFData{Signals: [][]int16{make([]int16, numsamp)}}
+	if err = enc.Encode(short); err == nil {
+		t.Error("For TestEDFEncoder\n", "Expected error for mismatched record")
+	}
+}
+
+func TestBDFEncoderFile(t *testing.T) {
+	fn := "./tstdata/testdata.bdf"
+	buf, err := ioutil.ReadFile(fn)
+	if os.IsNotExist(err) {
+		t.Logf("%s\nmissing BDF test data\n", err)
+		return
+	} else if err != nil {
+		t.Errorf("read test data file: %s\n", err)
+	}
+	bdf, err := UnmarshalBDF(buf)
+	if err != nil {
+		t.Errorf("unmarshal test file: %s\n", err)
+		return
+	}
+	var out bytes.Buffer
+	enc, err := NewBDFEncoder(&out, bdf.Header)
+	if err != nil {
+		t.Errorf("encode test file: %s\n", err)
+		return
+	}
+	for _, record := range bdf.DataRecords {
+		if err = enc.Encode(record); err != nil {
+			t.Errorf("encode test file: %s\n", err)
+			return
+		}
+	}
+	if !bytes.Equal(out.Bytes(), buf) {
+		t.Error("For TestBDFEncoderFile\n",
+			"Expected: ", len(buf), " bytes matching test file",
+			"Got: ", out.Len())
+	}
+}
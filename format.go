@@ -0,0 +1,124 @@
+package biosigio
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// Format identifies the flavour of a file from its version and reserved
+// fields
+type Format int
+
+const (
+	FormatUnknown Format = iota
+	FormatEDF
+	FormatEDFPlusC
+	FormatEDFPlusD
+	FormatBDF
+	FormatBDFPlusC
+	FormatBDFPlusD
+)
+
+var formatNames = map[Format]string{
+	FormatUnknown:  "unknown",
+	FormatEDF:      "EDF",
+	FormatEDFPlusC: EDFPlusContinuous,
+	FormatEDFPlusD: EDFPlusDiscontinuous,
+	FormatBDF:      "BDF",
+	FormatBDFPlusC: BDFPlusContinuous,
+	FormatBDFPlusD: BDFPlusDiscontinuous,
+}
+
+func (f Format) String() string {
+	if name, ok := formatNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// BDF reports whether the format stores 3-byte samples
+func (f Format) BDF() bool {
+	return f == FormatBDF || f == FormatBDFPlusC || f == FormatBDFPlusD
+}
+
+// detectFormat tells the formats apart from the version and reserved fields
+func detectFormat(version [8]byte, reserved string) Format {
+	switch version {
+	case EDFVersion:
+		switch {
+		case strings.HasPrefix(reserved, EDFPlusContinuous):
+			return FormatEDFPlusC
+		case strings.HasPrefix(reserved, EDFPlusDiscontinuous):
+			return FormatEDFPlusD
+		}
+		return FormatEDF
+	case BDFVersion:
+		switch {
+		case strings.HasPrefix(reserved, BDFPlusContinuous):
+			return FormatBDFPlusC
+		case strings.HasPrefix(reserved, BDFPlusDiscontinuous):
+			return FormatBDFPlusD
+		}
+		return FormatBDF
+	}
+	return FormatUnknown
+}
+
+// Format identifies the flavour of the file the header describes
+func (h *Header) Format() Format {
+	return detectFormat(h.version, string(h.reserved[:]))
+}
+
+// DetectFormat sniffs the version and reserved fields at the start of buf
+func DetectFormat(buf []byte) (Format, error) {
+	if len(buf) < FixedHeaderBytes {
+		return FormatUnknown, fmt.Errorf("buf has %v bytes, fixed header needs %v", len(buf), FixedHeaderBytes)
+	}
+	foffset := fixedHeaderOffsets()
+	var version [8]byte
+	copy(version[:], buf)
+	offset := numdatarOffset() - foffset["reserved"]
+	f := detectFormat(version, string(buf[offset:offset+foffset["reserved"]]))
+	if f == FormatUnknown {
+		return f, fmt.Errorf("version %q is neither EDF nor BDF", buf[:len(version)])
+	}
+	return f, nil
+}
+
+// Recording is implemented by *EDF and *BDF. A type switch on a Recording
+// gives access to the header and the samples.
+type Recording interface {
+	Format() Format
+	Validate() []*FieldError
+	Physical(idx int) ([][]float64, error)
+	Annotations() ([]Annotation, error)
+	Segments() ([]Segment, error)
+}
+
+// Format identifies the flavour of the file from its header
+func (f *File[T]) Format() Format {
+	return f.Header.Format()
+}
+
+// Unmarshal sniffs the version field of buf and unmarshals it into an *EDF
+// or a *BDF
+func Unmarshal(buf []byte) (Recording, error) {
+	f, err := DetectFormat(buf)
+	if err != nil {
+		return nil, err
+	}
+	if f.BDF() {
+		return UnmarshalBDF(buf)
+	}
+	return UnmarshalEDF(buf)
+}
+
+// Open reads the named file and unmarshals it into an *EDF or a *BDF
+func Open(name string) (Recording, error) {
+	buf, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return Unmarshal(buf)
+}
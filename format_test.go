@@ -0,0 +1,87 @@
+package biosigio
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	for _, tc := range []struct {
+		version  string
+		reserved string
+		expected Format
+	}{
+		{"0", "", FormatEDF},
+		{"0", EDFPlusContinuous, FormatEDFPlusC},
+		{"0", EDFPlusDiscontinuous, FormatEDFPlusD},
+		{string(BDFVersion[:]), "24BIT", FormatBDF},
+		{string(BDFVersion[:]), BDFPlusContinuous, FormatBDFPlusC},
+		{string(BDFVersion[:]), BDFPlusDiscontinuous, FormatBDFPlusD},
+	} {
+		h, err := NewHeader(Version(tc.version), Reserved(tc.reserved), NumSignal("0"))
+		if err != nil {
+			t.Error("For TestDetectFormat\n", err)
+			continue
+		}
+		buf, err := h.appendContents(nil)
+		if err != nil {
+			t.Error("For TestDetectFormat\n", err)
+			continue
+		}
+		f, err := DetectFormat(buf)
+		if err != nil || f != tc.expected || h.Format() != tc.expected {
+			t.Error("For TestDetectFormat\n",
+				"Expected: ", tc.expected,
+				"Got: ", f, h.Format(), err)
+		}
+	}
+	buf := make([]byte, FixedHeaderBytes)
+	copy(buf, "1.0")
+	if _, err := DetectFormat(buf); err == nil {
+		t.Error("For TestDetectFormat\n", "Expected error for unknown version")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	h, err := NewHeader(Version(string(BDFVersion[:])), NumDataRecord("1"),
+		NumSignal("1"), NumSamples([]string{"2"}))
+	if err != nil {
+		t.Error("For TestOpen\n", err)
+		return
+	}
+	buf, err := MarshalBDF(NewBDF(h, []*BDFData{{Signals: [][]int32{{-70000, 70000}}}}))
+	if err != nil {
+		t.Error("For TestOpen\n", err)
+		return
+	}
+	dir, err := ioutil.TempDir("", "biosigio")
+	if err != nil {
+		t.Error("For TestOpen\n", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	fn := filepath.Join(dir, "recording.edf")
+	if err = ioutil.WriteFile(fn, buf, 0644); err != nil {
+		t.Error("For TestOpen\n", err)
+		return
+	}
+	rec, err := Open(fn)
+	if err != nil {
+		t.Error("For TestOpen\n", err)
+		return
+	}
+	bdf, ok := rec.(*BDF)
+	if !ok || rec.Format() != FormatBDF {
+		t.Error("For TestOpen\n",
+			"Expected: ", FormatBDF,
+			"Got: ", rec.Format())
+		return
+	}
+	if bdf.DataRecords[0].Signals[0][1] != 70000 {
+		t.Error("For TestOpen\n",
+			"Expected: ", 70000,
+			"Got: ", bdf.DataRecords[0].Signals[0][1])
+	}
+}
@@ -0,0 +1,164 @@
+package biosigio
+
+import (
+	"time"
+)
+
+// Version returns the version of the data format
+func (h *Header) Version() string {
+	return trimField(h.version[:])
+}
+
+// LocalPatientID returns the local patient identification
+func (h *Header) LocalPatientID() string {
+	return trimField(h.LPID[:])
+}
+
+// LocalRecordID returns the local recording identification
+func (h *Header) LocalRecordID() string {
+	return trimField(h.LRID[:])
+}
+
+// StartTime returns the start of the recording, or the zero time if the
+// startdate or starttime field does not parse
+func (h *Header) StartTime() time.Time {
+	t, err := h.startTime()
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// NumBytes returns the number of bytes in the header record
+func (h *Header) NumBytes() int {
+	n, _ := asciiToInt(h.numbytes[:])
+	return n
+}
+
+// Reserved returns the reserved field, which holds EDF+C or EDF+D in EDF+
+func (h *Header) Reserved() string {
+	return trimField(h.reserved[:])
+}
+
+// NumDataRecords returns the number of data records, UnknownNumDataRecord
+// while a recording is in progress
+func (h *Header) NumDataRecords() int {
+	n, _ := asciiToInt(h.numdatar[:])
+	return n
+}
+
+// RecordDuration returns the duration of a data record
+func (h *Header) RecordDuration() time.Duration {
+	d, _ := asciiToDuration(h.duration[:])
+	return d
+}
+
+// NumSignals returns the number of signals in a data record
+func (h *Header) NumSignals() int {
+	n, _ := asciiToInt(h.numsignal[:])
+	return n
+}
+
+// Labels returns the label of each signal
+func (h *Header) Labels() []string {
+	labels := make([]string, len(h.label))
+	for idx, val := range h.label {
+		labels[idx] = trimField(val[:])
+	}
+	return labels
+}
+
+// TransducerTypes returns the transducer type of each signal
+func (h *Header) TransducerTypes() []string {
+	tts := make([]string, len(h.transducerType))
+	for idx, val := range h.transducerType {
+		tts[idx] = trimField(val[:])
+	}
+	return tts
+}
+
+// PhysicalDimensions returns the physical dimension of each signal
+func (h *Header) PhysicalDimensions() []string {
+	phydims := make([]string, len(h.phydim))
+	for idx, val := range h.phydim {
+		phydims[idx] = trimField(val[:])
+	}
+	return phydims
+}
+
+// PhysicalMins returns the physical minimum of each signal
+func (h *Header) PhysicalMins() []float64 {
+	phymins := make([]float64, len(h.phymin))
+	for idx, val := range h.phymin {
+		phymins[idx], _ = asciiToFloat(val[:])
+	}
+	return phymins
+}
+
+// PhysicalMaxs returns the physical maximum of each signal
+func (h *Header) PhysicalMaxs() []float64 {
+	phymaxs := make([]float64, len(h.phymax))
+	for idx, val := range h.phymax {
+		phymaxs[idx], _ = asciiToFloat(val[:])
+	}
+	return phymaxs
+}
+
+// DigitalMins returns the digital minimum of each signal
+func (h *Header) DigitalMins() []int {
+	digmins := make([]int, len(h.digmin))
+	for idx, val := range h.digmin {
+		digmins[idx], _ = asciiToInt(val[:])
+	}
+	return digmins
+}
+
+// DigitalMaxs returns the digital maximum of each signal
+func (h *Header) DigitalMaxs() []int {
+	digmaxs := make([]int, len(h.digmax))
+	for idx, val := range h.digmax {
+		digmaxs[idx], _ = asciiToInt(val[:])
+	}
+	return digmaxs
+}
+
+// Prefilters returns the prefiltering of each signal
+func (h *Header) Prefilters() []string {
+	prefilters := make([]string, len(h.prefilter))
+	for idx, val := range h.prefilter {
+		prefilters[idx] = trimField(val[:])
+	}
+	return prefilters
+}
+
+// NumSamples returns the number of samples in each data record per signal
+func (h *Header) NumSamples() []int {
+	numsamples := make([]int, len(h.numsample))
+	for idx, val := range h.numsample {
+		numsamples[idx], _ = asciiToInt(val[:])
+	}
+	return numsamples
+}
+
+// NSReserved returns the reserved field of each signal
+func (h *Header) NSReserved() []string {
+	nsreserved := make([]string, len(h.nsreserved))
+	for idx, val := range h.nsreserved {
+		nsreserved[idx] = trimField(val[:])
+	}
+	return nsreserved
+}
+
+// SampleRate returns the number of samples per second of signal idx, zero
+// when the signal does not exist or data records have no duration
+func (h *Header) SampleRate(idx int) float64 {
+	if idx < 0 || idx >= len(h.numsample) {
+		return 0
+	}
+	numsample, _ := asciiToInt(h.numsample[idx][:])
+	duration := h.RecordDuration()
+	if duration <= 0 {
+		return 0
+	}
+	return float64(numsample) / duration.Seconds()
+}
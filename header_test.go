@@ -0,0 +1,53 @@
+package biosigio
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHeaderAccessorsFile(t *testing.T) {
+	fn := "./tstdata/testdata.bdf"
+	buf, err := ioutil.ReadFile(fn)
+	if os.IsNotExist(err) {
+		t.Logf("%s\nmissing BDF test data\n", err)
+		return
+	} else if err != nil {
+		t.Errorf("read test data file: %s\n", err)
+	}
+	bdf, err := UnmarshalBDF(buf)
+	if err != nil {
+		t.Errorf("unmarshal test file: %s\n", err)
+		return
+	}
+	h := bdf.Header
+	if h.Version() != "0" || h.LocalRecordID() != "Startdate 12-JAN-2015" || h.Reserved() != "EDF+C" {
+		t.Error("For TestHeaderAccessorsFile\n",
+			"Expected: ", "0, Startdate 12-JAN-2015, EDF+C",
+			"Got: ", h.Version(), h.LocalRecordID(), h.Reserved())
+	}
+	if h.NumBytes() != 2304 || h.NumDataRecords() != 1 || h.NumSignals() != 8 {
+		t.Error("For TestHeaderAccessorsFile\n",
+			"Expected: ", 2304, 1, 8,
+			"Got: ", h.NumBytes(), h.NumDataRecords(), h.NumSignals())
+	}
+	if h.RecordDuration() != 6347*time.Millisecond {
+		t.Error("For TestHeaderAccessorsFile\n",
+			"Expected: ", 6347*time.Millisecond,
+			"Got: ", h.RecordDuration())
+	}
+	expected := time.Date(2015, time.July, 12, 21, 18, 32, 0, time.UTC)
+	if !h.StartTime().Equal(expected) {
+		t.Error("For TestHeaderAccessorsFile\n",
+			"Expected: ", expected,
+			"Got: ", h.StartTime())
+	}
+	labels, phydims := h.Labels(), h.PhysicalDimensions()
+	phymins, digmaxs, numsamples := h.PhysicalMins(), h.DigitalMaxs(), h.NumSamples()
+	if len(labels) != 8 || labels[0] != "" || phydims[7] != "uv" || phymins[3] != -187500 ||
+		digmaxs[5] != 8388607 || numsamples[1] != 1550 {
+		t.Error("For TestHeaderAccessorsFile\n",
+			"Got: ", labels, phydims, phymins, digmaxs, numsamples)
+	}
+}
@@ -0,0 +1,146 @@
+package biosigio
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// unknownSubfield marks an EDF+ identification subfield whose value is not
+// known
+const unknownSubfield = "X"
+
+// edfDateLayout is the layout of dates such as 02-AUG-1951 in the EDF+
+// identification subfields, month names being upper case
+const edfDateLayout = "02-Jan-2006"
+
+// parseEDFDate parses a date of the form dd-MMM-yyyy
+func parseEDFDate(s string) (time.Time, error) {
+	if len(s) != len(edfDateLayout) {
+		return time.Time{}, fmt.Errorf("%q is not of the form dd-MMM-yyyy", s)
+	}
+	month := s[3:4] + strings.ToLower(s[4:6])
+	t, err := time.Parse(edfDateLayout, s[:3]+month+s[6:])
+	if err != nil || strings.ToUpper(s) != s {
+		return time.Time{}, fmt.Errorf("%q is not of the form dd-MMM-yyyy", s)
+	}
+	return t, nil
+}
+
+// formatEDFDate formats t as dd-MMM-yyyy
+func formatEDFDate(t time.Time) string {
+	return strings.ToUpper(t.Format(edfDateLayout))
+}
+
+// parseSubfield decodes a subfield, X standing for unknown and underscores
+// for spaces
+func parseSubfield(s string) string {
+	if s == unknownSubfield {
+		return ""
+	}
+	return strings.Replace(s, "_", " ", -1)
+}
+
+// formatSubfield encodes a subfield, writing X when unknown and underscores
+// for spaces
+func formatSubfield(s string) string {
+	if s == "" {
+		return unknownSubfield
+	}
+	return strings.Replace(s, " ", "_", -1)
+}
+
+// checkIdentification checks a formatted identification fits its 80-byte
+// field and holds only printable ascii
+func checkIdentification(s string) error {
+	if len(s) > 80 {
+		return fmt.Errorf("%q is longer than 80 bytes", s)
+	}
+	for _, c := range []byte(s) {
+		if c < 32 || c > 126 {
+			return fmt.Errorf("%s for %v in %q", errNotPrintable, c, s)
+		}
+	}
+	return nil
+}
+
+// PatientID holds the subfields of the EDF+ local patient identification.
+// Empty strings and the zero Birthdate stand for unknown values.
+type PatientID struct {
+	Code       string
+	Sex        string
+	Birthdate  time.Time
+	Name       string
+	Additional []string
+}
+
+// ParsePatientID parses an EDF+ local patient identification of the form
+// "code sex dd-MMM-yyyy name [additional ...]"
+func ParsePatientID(s string) (p PatientID, err error) {
+	fields := strings.Fields(s)
+	if len(fields) < 4 {
+		return p, fmt.Errorf("patient identification %q must hold code, sex, birthdate and name", s)
+	}
+	p.Code = parseSubfield(fields[0])
+	switch fields[1] {
+	case "M", "F":
+		p.Sex = fields[1]
+	case unknownSubfield:
+	default:
+		return p, fmt.Errorf("patient sex %q must be M, F or X", fields[1])
+	}
+	if fields[2] != unknownSubfield {
+		if p.Birthdate, err = parseEDFDate(fields[2]); err != nil {
+			return p, fmt.Errorf("patient birthdate: %v", err)
+		}
+	}
+	p.Name = parseSubfield(fields[3])
+	for _, field := range fields[4:] {
+		p.Additional = append(p.Additional, parseSubfield(field))
+	}
+	return p, nil
+}
+
+// String formats the patient identification as stored in the header
+func (p PatientID) String() string {
+	birthdate := unknownSubfield
+	if !p.Birthdate.IsZero() {
+		birthdate = formatEDFDate(p.Birthdate)
+	}
+	fields := []string{formatSubfield(p.Code), formatSubfield(p.Sex), birthdate, formatSubfield(p.Name)}
+	for _, val := range p.Additional {
+		fields = append(fields, formatSubfield(val))
+	}
+	return strings.Join(fields, " ")
+}
+
+// Validate checks the patient identification can be stored in the header
+func (p PatientID) Validate() error {
+	if p.Sex != "" && p.Sex != "M" && p.Sex != "F" {
+		return fmt.Errorf("patient sex %q must be M, F or empty", p.Sex)
+	}
+	if !p.Birthdate.IsZero() && (p.Birthdate.Year() < 1000 || p.Birthdate.Year() > 9999) {
+		return fmt.Errorf("patient birthdate %v must have a 4-digit year", p.Birthdate)
+	}
+	for _, val := range append([]string{p.Code, p.Name}, p.Additional...) {
+		if strings.ContainsAny(val, "_\t\n") {
+			return fmt.Errorf("patient subfield %q must not hold underscores or whitespace other than spaces", val)
+		}
+	}
+	return checkIdentification(p.String())
+}
+
+// PatientID parses the local patient identification as EDF+ subfields
+func (h *Header) PatientID() (PatientID, error) {
+	return ParsePatientID(trimField(h.LPID[:]))
+}
+
+// PatientIdentification setter, writing p as the local patient identification
+func PatientIdentification(p PatientID) func(*Header) error {
+	return func(h *Header) error {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		return h.setLPID(p.String())
+	}
+}
@@ -0,0 +1,68 @@
+package biosigio
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPatientID(t *testing.T) {
+	p, err := ParsePatientID("MCH-0234567 F 02-MAY-1951 Haagse_Harry extra")
+	if err != nil {
+		t.Error("For TestPatientID\n", err)
+		return
+	}
+	expected := PatientID{
+		Code:       "MCH-0234567",
+		Sex:        "F",
+		Birthdate:  time.Date(1951, time.May, 2, 0, 0, 0, 0, time.UTC),
+		Name:       "Haagse Harry",
+		Additional: []string{"extra"},
+	}
+	if p.Code != expected.Code || p.Sex != expected.Sex || !p.Birthdate.Equal(expected.Birthdate) ||
+		p.Name != expected.Name || len(p.Additional) != 1 || p.Additional[0] != "extra" {
+		t.Error("For TestPatientID\n",
+			"Expected: ", expected,
+			"Got: ", p)
+	}
+	if s := p.String(); s != "MCH-0234567 F 02-MAY-1951 Haagse_Harry extra" {
+		t.Error("For TestPatientID\n",
+			"Expected: ", "MCH-0234567 F 02-MAY-1951 Haagse_Harry extra",
+			"Got: ", s)
+	}
+
+	unknown, err := ParsePatientID("X X X X")
+	if err != nil || unknown.Code != "" || unknown.Sex != "" || !unknown.Birthdate.IsZero() || unknown.Name != "" {
+		t.Error("For TestPatientID\n",
+			"Expected: ", PatientID{},
+			"Got: ", unknown, err)
+	}
+	if s := (PatientID{}).String(); s != "X X X X" {
+		t.Error("For TestPatientID\n",
+			"Expected: ", "X X X X",
+			"Got: ", s)
+	}
+
+	h, err := NewHeader(NumSignal("1"), PatientIdentification(expected))
+	if err != nil {
+		t.Error("For TestPatientID\n", err)
+		return
+	}
+	p, err = h.PatientID()
+	if err != nil || p.Name != "Haagse Harry" || h.LocalPatientID() != expected.String() {
+		t.Error("For TestPatientID\n",
+			"Expected: ", expected.String(),
+			"Got: ", h.LocalPatientID(), err)
+	}
+
+	for _, bad := range []string{"MCH F 02-MAY-1951", "MCH Q X X", "MCH F 1951-05-02 X", "MCH F 31-FEB-1951 X", "MCH F 02-may-1951 X"} {
+		if _, err = ParsePatientID(bad); err == nil {
+			t.Errorf("For TestPatientID\nExpected error for %q", bad)
+		}
+	}
+	for _, bad := range []PatientID{{Sex: "male"}, {Name: "a_b"}, {Name: strings.Repeat("a", 80)}} {
+		if _, err = NewHeader(NumSignal("1"), PatientIdentification(bad)); err == nil {
+			t.Errorf("For TestPatientID\nExpected error for %q", bad.String())
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package biosigio
+
+import (
+	"fmt"
+	"math"
+)
+
+// scale maps the digital samples of one signal linearly onto physical units,
+// digmin to phymin and digmax to phymax
+type scale struct {
+	gain   float64
+	offset float64
+	digmin int
+	digmax int
+}
+
+func (s scale) physical(digital int) float64 {
+	return float64(digital)*s.gain + s.offset
+}
+
+// digital quantizes a physical value, clipping it to [digmin, digmax]
+func (s scale) digital(physical float64) int {
+	d := math.Round((physical - s.offset) / s.gain)
+	if math.IsNaN(d) || d < float64(s.digmin) {
+		return s.digmin
+	}
+	if d > float64(s.digmax) {
+		return s.digmax
+	}
+	return int(d)
+}
+
+// signalScale parses the physical and digital range of signal idx
+func (h *Header) signalScale(idx int) (s scale, err error) {
+	phymin, err := asciiToFloat(h.phymin[idx][:])
+	if err != nil {
+		return s, fmt.Errorf("signal %v physical minimum: %v", idx, err)
+	}
+	phymax, err := asciiToFloat(h.phymax[idx][:])
+	if err != nil {
+		return s, fmt.Errorf("signal %v physical maximum: %v", idx, err)
+	}
+	digmin, err := asciiToInt(h.digmin[idx][:])
+	if err != nil {
+		return s, fmt.Errorf("signal %v digital minimum: %v", idx, err)
+	}
+	digmax, err := asciiToInt(h.digmax[idx][:])
+	if err != nil {
+		return s, fmt.Errorf("signal %v digital maximum: %v", idx, err)
+	}
+	if digmin >= digmax {
+		return s, fmt.Errorf("signal %v digital minimum [%v] must be less than digital maximum [%v]",
+			idx, digmin, digmax)
+	}
+	if phymin == phymax {
+		return s, fmt.Errorf("signal %v physical minimum must differ from physical maximum [%v]",
+			idx, phymax)
+	}
+	gain := (phymax - phymin) / float64(digmax-digmin)
+	return scale{
+		gain:   gain,
+		offset: phymin - float64(digmin)*gain,
+		digmin: digmin,
+		digmax: digmax,
+	}, nil
+}
+
+// scales parses the physical and digital ranges of the signals holding
+// samples, marking annotation signals to be skipped
+func (h *Header) scales() (scales []scale, isAnn map[int]bool, err error) {
+	isAnn = make(map[int]bool)
+	for _, idx := range h.AnnotationSignals() {
+		isAnn[idx] = true
+	}
+	scales = make([]scale, len(h.label))
+	for idx := range scales {
+		if isAnn[idx] {
+			continue
+		}
+		if scales[idx], err = h.signalScale(idx); err != nil {
+			return nil, nil, err
+		}
+	}
+	return scales, isAnn, nil
+}
+
+// checkPhysical verifies physical values match the layout given by the header
+func (h *Header) checkPhysical(physical [][]float64, isAnn map[int]bool) (numsample []int, err error) {
+	numsample, err = h.sampleCounts()
+	if err != nil {
+		return nil, err
+	}
+	if len(physical) != len(numsample) {
+		return nil, fmt.Errorf("number of signals [%v] must equal length of physical array [%v]",
+			len(numsample), len(physical))
+	}
+	for idx, signal := range physical {
+		if !isAnn[idx] && len(signal) != numsample[idx] {
+			return nil, fmt.Errorf("number of samples [%v] must equal length of signal %v [%v]",
+				numsample[idx], idx, len(signal))
+		}
+	}
+	return numsample, nil
+}
+
+// sampleRange returns the smallest and largest sample of type T
+func sampleRange[T Sample]() (min, max int) {
+	if sampleSize[T]() == EDFDataByteSize {
+		return edfSampleMin, edfSampleMax
+	}
+	return bdfSampleMin, bdfSampleMax
+}
+
+// Physical converts the signals of data record idx to physical units using
+// the physical and digital minimum and maximum of each signal. Annotation
+// signals are returned as nil.
+func (f *File[T]) Physical(idx int) (physical [][]float64, err error) {
+	if idx < 0 || idx >= len(f.DataRecords) {
+		return nil, fmt.Errorf("data record %v out of range [0, %v)", idx, len(f.DataRecords))
+	}
+	scales, isAnn, err := f.Header.scales()
+	if err != nil {
+		return nil, err
+	}
+	physical = make([][]float64, len(f.DataRecords[idx].Signals))
+	for ids, signal := range f.DataRecords[idx].Signals {
+		if isAnn[ids] {
+			continue
+		}
+		physical[ids] = make([]float64, len(signal))
+		for idy, val := range signal {
+			physical[ids][idy] = scales[ids].physical(int(val))
+		}
+	}
+	return physical, nil
+}
+
+// NewRecordPhysical builds a data record from values in physical units,
+// quantizing and clipping them to the digital range of each signal. The
+// samples of annotation signals are zeroed, to be filled by SetAnnotations.
+func NewRecordPhysical[T Sample](h *Header, physical [][]float64) (*Record[T], error) {
+	scales, isAnn, err := h.scales()
+	if err != nil {
+		return nil, err
+	}
+	numsample, err := h.checkPhysical(physical, isAnn)
+	if err != nil {
+		return nil, err
+	}
+	sampleMin, sampleMax := sampleRange[T]()
+	d := &Record[T]{Signals: make([][]T, len(physical))}
+	for ids, signal := range physical {
+		d.Signals[ids] = make([]T, numsample[ids])
+		if isAnn[ids] {
+			continue
+		}
+		s := scales[ids]
+		if s.digmin < sampleMin {
+			s.digmin = sampleMin
+		}
+		if s.digmax > sampleMax {
+			s.digmax = sampleMax
+		}
+		for idy, val := range signal {
+			d.Signals[ids][idy] = T(s.digital(val))
+		}
+	}
+	return d, nil
+}
+
+// NewEDFDataPhysical builds an EDF data record from values in physical units
+func NewEDFDataPhysical(h *Header, physical [][]float64) (*EDFData, error) {
+	return NewRecordPhysical[int16](h, physical)
+}
+
+// NewBDFDataPhysical builds a BDF data record from values in physical units
+func NewBDFDataPhysical(h *Header, physical [][]float64) (*BDFData, error) {
+	return NewRecordPhysical[int32](h, physical)
+}
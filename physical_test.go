@@ -0,0 +1,91 @@
+package biosigio
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"testing"
+)
+
+func TestEDFPhysical(t *testing.T) {
+	h, err := NewHeader(Version("0"), NumDataRecord("1"), NumSignal("2"),
+		PhysicalMins([]string{"-500", "34"}), PhysicalMaxs([]string{"500", "40"}),
+		DigitalMins([]string{"-2048", "0"}), DigitalMaxs([]string{"2047", "600"}),
+		NumSamples([]string{"3", "2"}))
+	if err != nil {
+		t.Error("For TestEDFPhysical\n", err)
+		return
+	}
+	physical := [][]float64{{-500, 0.1, 1000}, {37.005, 12}}
+	d, err := NewEDFDataPhysical(h, physical)
+	if err != nil {
+		t.Error("For TestEDFPhysical\n", err)
+		return
+	}
+	expected := [][]int16{{-2048, 0, 2047}, {301, 0}}
+	for idx, signal := range expected {
+		for idy, val := range signal {
+			if d.Signals[idx][idy] != val {
+				t.Error("For TestEDFPhysical\n",
+					"Expected: ", expected,
+					"Got: ", d.Signals)
+			}
+		}
+	}
+
+	edf := NewEDF(h, []*EDFData{d})
+	got, err := edf.Physical(0)
+	if err != nil {
+		t.Error("For TestEDFPhysical\n", err)
+		return
+	}
+	roundTrip := [][]float64{{-500, 2048*1000/4095.0 - 500, 500}, {37.01, 34}}
+	for idx, signal := range roundTrip {
+		for idy, val := range signal {
+			if math.Abs(got[idx][idy]-val) > 1e-6 {
+				t.Error("For TestEDFPhysical\n",
+					"Expected: ", roundTrip,
+					"Got: ", got)
+			}
+		}
+	}
+	if _, err = NewEDFDataPhysical(h, physical[:1]); err == nil {
+		t.Error("For TestEDFPhysical\n", "Expected error for missing signal")
+	}
+}
+
+func TestBDFPhysicalFile(t *testing.T) {
+	fn := "./tstdata/testdata.bdf"
+	buf, err := ioutil.ReadFile(fn)
+	if os.IsNotExist(err) {
+		t.Logf("%s\nmissing BDF test data\n", err)
+		return
+	} else if err != nil {
+		t.Errorf("read test data file: %s\n", err)
+	}
+	bdf, err := UnmarshalBDF(buf)
+	if err != nil {
+		t.Errorf("unmarshal test file: %s\n", err)
+		return
+	}
+	physical, err := bdf.Physical(0)
+	if err != nil {
+		t.Errorf("physical test file: %s\n", err)
+		return
+	}
+	d, err := NewBDFDataPhysical(bdf.Header, physical)
+	if err != nil {
+		t.Errorf("digital test file: %s\n", err)
+		return
+	}
+	for idx, signal := range d.Signals {
+		for idy, val := range signal {
+			if val != bdf.DataRecords[0].Signals[idx][idy] {
+				t.Error("For TestBDFPhysicalFile\n",
+					"Expected: ", bdf.DataRecords[0].Signals[idx][idy],
+					"Got: ", val)
+				return
+			}
+		}
+	}
+}
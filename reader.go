@@ -0,0 +1,166 @@
+package biosigio
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Reader reads individual data records from a random access input without
+// reading the rest of the file, locating each data record by its byte offset
+type Reader[T Sample] struct {
+	Header     *Header
+	ra         io.ReaderAt
+	numsample  []int
+	headerSize int64
+	recordSize int64
+	numdatar   int
+	duration   time.Duration
+}
+
+// EDFReader reads individual EDF data records from a random access input
+type EDFReader = Reader[int16]
+
+// BDFReader reads individual BDF data records from a random access input
+type BDFReader = Reader[int32]
+
+// NewReader reads the header from ra, which holds size bytes
+func NewReader[T Sample](ra io.ReaderAt, size int64) (r *Reader[T], err error) {
+	r = &Reader[T]{ra: ra}
+	r.Header, err = readHeader(io.NewSectionReader(ra, 0, size))
+	if err != nil {
+		return nil, err
+	}
+	nb, err := asciiToInt(r.Header.numbytes[:])
+	if err != nil {
+		return nil, fmt.Errorf("serialize ascii to int: %v, for %v", err, r.Header.numbytes)
+	}
+	r.headerSize = int64(nb)
+	r.numsample, err = r.Header.sampleCounts()
+	if err != nil {
+		return nil, err
+	}
+	byteSize := sampleSize[T]()
+	rs, err := r.Header.recordSize(byteSize)
+	if err != nil {
+		return nil, err
+	}
+	r.recordSize = int64(rs)
+	r.numdatar, err = r.Header.dataRecordCount(int(size-r.headerSize), byteSize)
+	if err != nil {
+		return nil, err
+	}
+	r.duration, err = asciiToDuration(r.Header.duration[:])
+	if err != nil {
+		return nil, fmt.Errorf("serialize ascii to duration: %v, for %v", err, r.Header.duration)
+	}
+	return r, nil
+}
+
+// NewEDFReader reads the header from ra, which holds size bytes
+func NewEDFReader(ra io.ReaderAt, size int64) (*EDFReader, error) {
+	return NewReader[int16](ra, size)
+}
+
+// NewBDFReader reads the header from ra, which holds size bytes
+func NewBDFReader(ra io.ReaderAt, size int64) (*BDFReader, error) {
+	return NewReader[int32](ra, size)
+}
+
+// NumDataRecords returns the number of data records available to the reader
+func (r *Reader[T]) NumDataRecords() int {
+	return r.numdatar
+}
+
+// ReadRecord reads data record idx
+func (r *Reader[T]) ReadRecord(idx int) (*Record[T], error) {
+	if idx < 0 || idx >= r.numdatar {
+		return nil, fmt.Errorf("data record %v out of range [0, %v)", idx, r.numdatar)
+	}
+	raw := make([]byte, r.recordSize)
+	// ReadAt may report io.EOF along with a full read at the end of input
+	if n, err := r.ra.ReadAt(raw, r.headerSize+int64(idx)*r.recordSize); n != len(raw) {
+		return nil, fmt.Errorf("read data record %v: %v", idx, err)
+	}
+	d := &Record[T]{}
+	if err := d.unmarshalSignals(raw, r.numsample); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// recordStart reads the start of data record idx from its time-keeping
+// annotation
+func (r *Reader[T]) recordStart(idx int) (time.Duration, error) {
+	d, err := r.ReadRecord(idx)
+	if err != nil {
+		return 0, err
+	}
+	return NewFile(r.Header, []*Record[T]{d}).RecordStart(0)
+}
+
+// recordRange returns the indices [first, last) of the data records
+// overlapping the time range [start, end) from the start of the recording.
+// The data records of EDF+D files are located by binary search over their
+// time-keeping annotations.
+func (r *Reader[T]) recordRange(start, end time.Duration) (first, last int, err error) {
+	if r.duration <= 0 {
+		return 0, 0, fmt.Errorf("data record duration %v does not allow time ranges", r.duration)
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("end of time range %v before start %v", end, start)
+	}
+	if r.Header.Discontinuous() {
+		search := func(after func(time.Duration) bool) int {
+			return sort.Search(r.numdatar, func(idx int) bool {
+				rstart, serr := r.recordStart(idx)
+				if serr != nil {
+					err = serr
+					return true
+				}
+				return after(rstart)
+			})
+		}
+		first = search(func(rstart time.Duration) bool { return rstart+r.duration > start })
+		last = search(func(rstart time.Duration) bool { return rstart >= end })
+		if err != nil {
+			return 0, 0, err
+		}
+		if first > last {
+			first = last
+		}
+		return first, last, nil
+	}
+	if start < 0 {
+		start = 0
+	}
+	first = int(start / r.duration)
+	last = int((end + r.duration - 1) / r.duration)
+	if last > r.numdatar {
+		last = r.numdatar
+	}
+	if first > last {
+		first = last
+	}
+	return first, last, nil
+}
+
+// ReadTimeRange reads the data records overlapping the time range
+// [start, end) measured from the start of the recording, following the
+// time-keeping annotations of EDF+D files
+func (r *Reader[T]) ReadTimeRange(start, end time.Duration) ([]*Record[T], error) {
+	first, last, err := r.recordRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	d := make([]*Record[T], 0, last-first)
+	for idx := first; idx < last; idx++ {
+		record, err := r.ReadRecord(idx)
+		if err != nil {
+			return nil, err
+		}
+		d = append(d, record)
+	}
+	return d, nil
+}
@@ -0,0 +1,191 @@
+package biosigio
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+// eofReaderAt reports io.EOF along with reads reaching the end of its bytes,
+// as io.ReaderAt allows
+type eofReaderAt struct {
+	buf []byte
+}
+
+func (e *eofReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= int64(len(e.buf)) {
+		return 0, io.EOF
+	}
+	n := copy(p, e.buf[off:])
+	if off+int64(n) == int64(len(e.buf)) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func TestEDFReader(t *testing.T) {
+	numrec := 4
+	h, err := NewHeader(Version("0"), NumDataRecord("4"), Duration("0.5"),
+		NumSignal("2"), NumSamples([]string{"4", "2"}))
+	if err != nil {
+		t.Error("For TestEDFReader\n", err)
+		return
+	}
+	records := make([]*EDFData, numrec)
+	for idr := range records {
+		n := int16(idr * 10)
+		records[idr] = &EDFData{Signals: [][]int16{{n, n + 1, n + 2, n + 3}, {-n, -n - 1}}}
+	}
+	buf, err := MarshalEDF(NewEDF(h, records))
+	if err != nil {
+		t.Error("For TestEDFReader\n", err)
+		return
+	}
+	r, err := NewEDFReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		t.Error("For TestEDFReader\n", err)
+		return
+	}
+	if r.NumDataRecords() != numrec {
+		t.Error("For TestEDFReader\n",
+			"Expected: ", numrec,
+			"Got: ", r.NumDataRecords())
+	}
+	record, err := r.ReadRecord(2)
+	if err != nil {
+		t.Error("For TestEDFReader\n", err)
+		return
+	}
+	if record.Signals[0][3] != 23 || record.Signals[1][1] != -21 {
+		t.Error("For TestEDFReader\n",
+			"Expected: ", records[2].Signals,
+			"Got: ", record.Signals)
+	}
+	if _, err = r.ReadRecord(numrec); err == nil {
+		t.Error("For TestEDFReader\n", "Expected error for record out of range")
+	}
+	eofReader, err := NewEDFReader(&eofReaderAt{buf: buf}, int64(len(buf)))
+	if err != nil {
+		t.Error("For TestEDFReader\n", err)
+		return
+	}
+	if record, err = eofReader.ReadRecord(numrec - 1); err != nil || record.Signals[0][0] != 30 {
+		t.Error("For TestEDFReader\n",
+			"Expected: ", records[numrec-1].Signals,
+			"Got: ", record, err)
+	}
+	short, err := NewEDFReader(&eofReaderAt{buf: buf[:len(buf)-1]}, int64(len(buf)))
+	if err != nil {
+		t.Error("For TestEDFReader\n", err)
+		return
+	}
+	if _, err = short.ReadRecord(numrec - 1); err == nil {
+		t.Error("For TestEDFReader\n", "Expected error for short read")
+	}
+
+	d, err := r.ReadTimeRange(700*time.Millisecond, 1500*time.Millisecond)
+	if err != nil {
+		t.Error("For TestEDFReader\n", err)
+		return
+	}
+	if len(d) != 2 || d[0].Signals[0][0] != 10 || d[1].Signals[0][0] != 20 {
+		t.Error("For TestEDFReader\n",
+			"Expected: records 1 and 2",
+			"Got: ", len(d), " records")
+	}
+	d, err = r.ReadTimeRange(time.Second, time.Hour)
+	if err != nil || len(d) != 2 {
+		t.Error("For TestEDFReader\n",
+			"Expected: 2 records",
+			"Got: ", len(d), err)
+	}
+}
+
+func TestBDFReaderFile(t *testing.T) {
+	fn := "./tstdata/testdata.bdf"
+	buf, err := ioutil.ReadFile(fn)
+	if os.IsNotExist(err) {
+		t.Logf("%s\nmissing BDF test data\n", err)
+		return
+	} else if err != nil {
+		t.Errorf("read test data file: %s\n", err)
+	}
+	bdf, err := UnmarshalBDF(buf)
+	if err != nil {
+		t.Errorf("unmarshal test file: %s\n", err)
+		return
+	}
+	r, err := NewBDFReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		t.Errorf("read test file: %s\n", err)
+		return
+	}
+	record, err := r.ReadRecord(0)
+	if err != nil {
+		t.Errorf("read test file: %s\n", err)
+		return
+	}
+	for idx, signal := range record.Signals {
+		for idy, val := range signal {
+			if val != bdf.DataRecords[0].Signals[idx][idy] {
+				t.Error("For TestBDFReaderFile\n",
+					"Expected: ", bdf.DataRecords[0].Signals[idx][idy],
+					"Got: ", val)
+				return
+			}
+		}
+	}
+}
+
+func TestReaderDiscontinuous(t *testing.T) {
+	var files []*EDF
+	for _, starttime := range []string{"10.00.00", "10.00.10"} {
+		edf, err := hourEDF(starttime, "EEG Fpz-Cz")
+		if err != nil {
+			t.Error("For TestReaderDiscontinuous\n", err)
+			return
+		}
+		files = append(files, edf)
+	}
+	edf, err := ConcatEDF(files...)
+	if err != nil {
+		t.Error("For TestReaderDiscontinuous\n", err)
+		return
+	}
+	buf, err := MarshalEDF(edf)
+	if err != nil {
+		t.Error("For TestReaderDiscontinuous\n", err)
+		return
+	}
+	r, err := NewEDFReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		t.Error("For TestReaderDiscontinuous\n", err)
+		return
+	}
+	// Data records start at 0s, 1s, 10s and 11s
+	for _, tc := range []struct {
+		start, end time.Duration
+		expected   int
+	}{
+		{1500 * time.Millisecond, 10500 * time.Millisecond, 2},
+		{2 * time.Second, 10 * time.Second, 0},
+		{10 * time.Second, time.Hour, 2},
+		{0, 2 * time.Second, 2},
+	} {
+		d, err := r.ReadTimeRange(tc.start, tc.end)
+		if err != nil || len(d) != tc.expected {
+			t.Error("For TestReaderDiscontinuous\n",
+				"Expected: ", tc.expected,
+				"Got: ", len(d), err)
+		}
+	}
+	d, err := r.ReadTimeRange(1500*time.Millisecond, 10500*time.Millisecond)
+	if err != nil || len(d) != 2 || d[0].Signals[0][0] != 3 || d[1].Signals[0][0] != 1 {
+		t.Error("For TestReaderDiscontinuous\n",
+			"Expected: ", "second data record of each file",
+			"Got: ", d, err)
+	}
+}
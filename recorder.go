@@ -0,0 +1,89 @@
+package biosigio
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// numdatarOffset is the byte offset of the number of data records in the
+// header record
+func numdatarOffset() (offset int) {
+	foffset := fixedHeaderOffsets()
+	for _, field := range []string{"version", "LPID", "LRID", "startdate",
+		"starttime", "numbytes", "reserved"} {
+		offset += foffset[field]
+	}
+	return offset
+}
+
+// Recorder writes a file whose number of data records is unknown until the
+// recording is closed. A file left behind by an interrupted recording can
+// still be read, as the number of data records is then derived from its size.
+type Recorder[T Sample] struct {
+	*Encoder[T]
+	ws     io.WriteSeeker
+	closed bool
+}
+
+// EDFRecorder writes an EDF file while a recording is in progress
+type EDFRecorder = Recorder[int16]
+
+// BDFRecorder writes a BDF file while a recording is in progress
+type BDFRecorder = Recorder[int32]
+
+// NewRecorder writes the header h to ws with the number of data records set
+// to UnknownNumDataRecord
+func NewRecorder[T Sample](ws io.WriteSeeker, h *Header) (*Recorder[T], error) {
+	if err := h.setNumDataRecord(strconv.Itoa(UnknownNumDataRecord)); err != nil {
+		return nil, err
+	}
+	e, err := NewEncoder[T](ws, h)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder[T]{Encoder: e, ws: ws}, nil
+}
+
+// NewEDFRecorder writes the header h to ws with the number of data records
+// set to UnknownNumDataRecord
+func NewEDFRecorder(ws io.WriteSeeker, h *Header) (*EDFRecorder, error) {
+	return NewRecorder[int16](ws, h)
+}
+
+// NewBDFRecorder writes the header h to ws with the number of data records
+// set to UnknownNumDataRecord
+func NewBDFRecorder(ws io.WriteSeeker, h *Header) (*BDFRecorder, error) {
+	return NewRecorder[int32](ws, h)
+}
+
+// Encode writes the data record d after those already written. It fails once
+// the recording is closed.
+func (r *Recorder[T]) Encode(d *Record[T]) error {
+	if r.closed {
+		return fmt.Errorf("recording is closed")
+	}
+	return r.Encoder.Encode(d)
+}
+
+// Close seeks back to the header and writes the number of data records
+// written. A failed Close may be retried.
+func (r *Recorder[T]) Close() error {
+	if r.closed {
+		return nil
+	}
+	if err := r.Header.setNumDataRecord(strconv.Itoa(r.count)); err != nil {
+		return err
+	}
+	if _, err := r.ws.Seek(int64(numdatarOffset()), io.SeekStart); err != nil {
+		return fmt.Errorf("seek to number of data records: %v", err)
+	}
+	if _, err := r.ws.Write(r.Header.numdatar[:]); err != nil {
+		return fmt.Errorf("write number of data records: %v", err)
+	}
+	if _, err := r.ws.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("seek to end of recording: %v", err)
+	}
+	r.closed = true
+	return nil
+}
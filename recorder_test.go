@@ -0,0 +1,160 @@
+package biosigio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+// seekBuffer is an in-memory io.WriteSeeker
+type seekBuffer struct {
+	buf []byte
+	pos int
+}
+
+func (s *seekBuffer) Write(p []byte) (int, error) {
+	if need := s.pos + len(p); need > len(s.buf) {
+		s.buf = append(s.buf, make([]byte, need-len(s.buf))...)
+	}
+	copy(s.buf[s.pos:], p)
+	s.pos += len(p)
+	return len(p), nil
+}
+
+func (s *seekBuffer) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		s.pos = int(offset)
+	case io.SeekCurrent:
+		s.pos += int(offset)
+	case io.SeekEnd:
+		s.pos = len(s.buf) + int(offset)
+	}
+	if s.pos < 0 {
+		return 0, errors.New("negative position")
+	}
+	return int64(s.pos), nil
+}
+
+func TestEDFRecorder(t *testing.T) {
+	numsig, numsamp, numrec := 2, 64, 5
+	h, err := NewHeader(Version("0"), NumSignal("2"), NumSamples([]string{"64", "64"}))
+	if err != nil {
+		t.Error("For TestEDFRecorder\n", err)
+		return
+	}
+	out := &seekBuffer{}
+	rec, err := NewEDFRecorder(out, h)
+	if err != nil {
+		t.Error("For TestEDFRecorder\n", err)
+		return
+	}
+	for idr := 0; idr < numrec; idr++ {
+		signals := make([][]int16, numsig)
+		for idx := range signals {
+			signals[idx] = make([]int16, numsamp)
+			for idy := range signals[idx] {
+				signals[idx][idy] = int16(rand.Intn(32767))
+			}
+		}
+		if err = rec.Encode(&EDFData{Signals: signals}); err != nil {
+			t.Error("For TestEDFRecorder\n", err)
+			return
+		}
+	}
+
+	// An interrupted recording derives its record count from the file size,
+	// dropping a partially written last data record
+	inProgress := append([]byte(nil), out.buf...)
+	inProgress = append(inProgress, make([]byte, numsamp)...)
+	edf, err := UnmarshalEDF(inProgress)
+	if err != nil {
+		t.Error("For TestEDFRecorder\n", err)
+		return
+	}
+	if len(edf.DataRecords) != numrec {
+		t.Error("For TestEDFRecorder\n",
+			"Expected: ", numrec,
+			"Got: ", len(edf.DataRecords))
+	}
+	dec, err := NewEDFDecoder(bytes.NewReader(inProgress))
+	if err != nil {
+		t.Error("For TestEDFRecorder\n", err)
+		return
+	}
+	var count int
+	for ; dec.Next(); count++ {
+	}
+	if dec.Err() != nil || count != numrec {
+		t.Error("For TestEDFRecorder\n",
+			"Expected: ", numrec,
+			"Got: ", count, dec.Err())
+	}
+
+	if err = rec.Close(); err != nil {
+		t.Error("For TestEDFRecorder\n", err)
+		return
+	}
+	edf, err = UnmarshalEDF(out.buf)
+	if err != nil {
+		t.Error("For TestEDFRecorder\n", err)
+		return
+	}
+	ndr, err := asciiToInt(edf.Header.numdatar[:])
+	if err != nil || ndr != numrec {
+		t.Error("For TestEDFRecorder\n",
+			"Expected: ", numrec,
+			"Got: ", string(edf.Header.numdatar[:]))
+	}
+	if err = rec.Encode(edf.DataRecords[0]); err == nil {
+		t.Error("For TestEDFRecorder\n", "Expected error for Encode after Close")
+	}
+}
+
+// failingSeeker is an io.WriteSeeker whose seeks fail until fail is cleared
+type failingSeeker struct {
+	seekBuffer
+	fail bool
+}
+
+func (f *failingSeeker) Seek(offset int64, whence int) (int64, error) {
+	if f.fail {
+		return 0, errors.New("seek failed")
+	}
+	return f.seekBuffer.Seek(offset, whence)
+}
+
+func TestRecorderCloseRetry(t *testing.T) {
+	h, err := NewHeader(Version("0"), NumSignal("1"), NumSamples([]string{"2"}))
+	if err != nil {
+		t.Error("For TestRecorderCloseRetry\n", err)
+		return
+	}
+	out := &failingSeeker{}
+	rec, err := NewEDFRecorder(out, h)
+	if err != nil {
+		t.Error("For TestRecorderCloseRetry\n", err)
+		return
+	}
+	if err = rec.Encode(&EDFData{Signals: [][]int16{{1, 2}}}); err != nil {
+		t.Error("For TestRecorderCloseRetry\n", err)
+		return
+	}
+	out.fail = true
+	if err = rec.Close(); err == nil {
+		t.Error("For TestRecorderCloseRetry\n", "Expected error for failed seek")
+	}
+	out.fail = false
+	if err = rec.Close(); err != nil {
+		t.Error("For TestRecorderCloseRetry\n", err)
+		return
+	}
+	edf, err := UnmarshalEDF(out.buf)
+	if err != nil || edf.Header.NumDataRecords() != 1 {
+		t.Error("For TestRecorderCloseRetry\n",
+			"Expected: ", 1,
+			"Got: ", edf, err)
+	}
+}
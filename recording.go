@@ -0,0 +1,97 @@
+package biosigio
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// recordingStartdate opens every EDF+ local recording identification
+const recordingStartdate = "Startdate"
+
+// RecordingID holds the subfields of the EDF+ local recording
+// identification. Empty strings and the zero Startdate stand for unknown
+// values.
+type RecordingID struct {
+	Startdate  time.Time
+	AdminCode  string
+	Technician string
+	Equipment  string
+	Additional []string
+}
+
+// ParseRecordingID parses an EDF+ local recording identification of the
+// form "Startdate dd-MMM-yyyy admincode technician equipment [additional ...]"
+func ParseRecordingID(s string) (r RecordingID, err error) {
+	fields := strings.Fields(s)
+	if len(fields) < 5 || fields[0] != recordingStartdate {
+		return r, fmt.Errorf("recording identification %q must hold Startdate, date, admin code, technician and equipment", s)
+	}
+	if fields[1] != unknownSubfield {
+		if r.Startdate, err = parseEDFDate(fields[1]); err != nil {
+			return r, fmt.Errorf("recording startdate: %v", err)
+		}
+	}
+	r.AdminCode = parseSubfield(fields[2])
+	r.Technician = parseSubfield(fields[3])
+	r.Equipment = parseSubfield(fields[4])
+	for _, field := range fields[5:] {
+		r.Additional = append(r.Additional, parseSubfield(field))
+	}
+	return r, nil
+}
+
+// String formats the recording identification as stored in the header
+func (r RecordingID) String() string {
+	startdate := unknownSubfield
+	if !r.Startdate.IsZero() {
+		startdate = formatEDFDate(r.Startdate)
+	}
+	fields := []string{recordingStartdate, startdate, formatSubfield(r.AdminCode),
+		formatSubfield(r.Technician), formatSubfield(r.Equipment)}
+	for _, val := range r.Additional {
+		fields = append(fields, formatSubfield(val))
+	}
+	return strings.Join(fields, " ")
+}
+
+// Validate checks the recording identification can be stored in the header
+func (r RecordingID) Validate() error {
+	if !r.Startdate.IsZero() && (r.Startdate.Year() < 1000 || r.Startdate.Year() > 9999) {
+		return fmt.Errorf("recording startdate %v must have a 4-digit year", r.Startdate)
+	}
+	for _, val := range append([]string{r.AdminCode, r.Technician, r.Equipment}, r.Additional...) {
+		if strings.ContainsAny(val, "_\t\n") {
+			return fmt.Errorf("recording subfield %q must not hold underscores or whitespace other than spaces", val)
+		}
+	}
+	return checkIdentification(r.String())
+}
+
+// RecordingID parses the local recording identification as EDF+ subfields
+func (h *Header) RecordingID() (RecordingID, error) {
+	return ParseRecordingID(trimField(h.LRID[:]))
+}
+
+// RecordingIdentification setter, writing r as the local recording
+// identification
+func RecordingIdentification(r RecordingID) func(*Header) error {
+	return func(h *Header) error {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		return h.setLRID(r.String())
+	}
+}
+
+// recordingDate returns the date of the Startdate subfield of the local
+// recording identification, the only place EDF+ stores a 4-digit year.
+// Unlike ParseRecordingID it accepts a field missing the later subfields.
+func (h *Header) recordingDate() (date time.Time, ok bool) {
+	fields := strings.Fields(trimField(h.LRID[:]))
+	if len(fields) < 2 || fields[0] != recordingStartdate {
+		return date, false
+	}
+	date, err := parseEDFDate(fields[1])
+	return date, err == nil
+}
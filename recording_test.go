@@ -0,0 +1,121 @@
+package biosigio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordingID(t *testing.T) {
+	r, err := ParseRecordingID("Startdate 02-MAR-2002 EMG561 BK/JOP Sony. MNC_R_Median_Nerve.")
+	if err != nil {
+		t.Error("For TestRecordingID\n", err)
+		return
+	}
+	if !r.Startdate.Equal(time.Date(2002, time.March, 2, 0, 0, 0, 0, time.UTC)) || r.AdminCode != "EMG561" ||
+		r.Technician != "BK/JOP" || r.Equipment != "Sony." || len(r.Additional) != 1 ||
+		r.Additional[0] != "MNC R Median Nerve." {
+		t.Error("For TestRecordingID\n",
+			"Expected: ", "Startdate 02-MAR-2002 EMG561 BK/JOP Sony. MNC_R_Median_Nerve.",
+			"Got: ", r)
+	}
+	if s := r.String(); s != "Startdate 02-MAR-2002 EMG561 BK/JOP Sony. MNC_R_Median_Nerve." {
+		t.Error("For TestRecordingID\n",
+			"Expected: ", "Startdate 02-MAR-2002 EMG561 BK/JOP Sony. MNC_R_Median_Nerve.",
+			"Got: ", s)
+	}
+	if s := (RecordingID{}).String(); s != "Startdate X X X X" {
+		t.Error("For TestRecordingID\n",
+			"Expected: ", "Startdate X X X X",
+			"Got: ", s)
+	}
+	for _, bad := range []string{"Startdate 02-MAR-2002 EMG561", "Recorded 02-MAR-2002 X X X", "Startdate 2002-03-02 X X X"} {
+		if _, err = ParseRecordingID(bad); err == nil {
+			t.Errorf("For TestRecordingID\nExpected error for %q", bad)
+		}
+	}
+	if _, err = NewHeader(NumSignal("1"), RecordingIdentification(RecordingID{Equipment: "a_b"})); err == nil {
+		t.Error("For TestRecordingID\n", "Expected error for underscore in subfield")
+	}
+}
+
+func TestStartTimeRecordingYear(t *testing.T) {
+	for _, tc := range []struct {
+		lrid     RecordingID
+		expected int
+	}{
+		{RecordingID{Startdate: time.Date(2089, time.February, 1, 0, 0, 0, 0, time.UTC)}, 2089},
+		{RecordingID{}, 1989},
+		// A Startdate subfield disagreeing with the startdate is ignored
+		{RecordingID{Startdate: time.Date(2090, time.February, 1, 0, 0, 0, 0, time.UTC)}, 1989},
+	} {
+		h, err := NewHeader(NumSignal("1"), Startdate("01.02.89"), Starttime("10.00.00"),
+			RecordingIdentification(tc.lrid))
+		if err != nil {
+			t.Error("For TestStartTimeRecordingYear\n", err)
+			return
+		}
+		if year := h.StartTime().Year(); year != tc.expected {
+			t.Error("For TestStartTimeRecordingYear\n",
+				"Expected: ", tc.expected,
+				"Got: ", year)
+		}
+		if r, err := h.RecordingID(); err != nil || !r.Startdate.Equal(tc.lrid.Startdate) {
+			t.Error("For TestStartTimeRecordingYear\n",
+				"Expected: ", tc.lrid,
+				"Got: ", r, err)
+		}
+	}
+
+	// Moving the start past 2084 updates the Startdate subfield
+	h, err := NewHeader(NumSignal("1"), Startdate("01.02.84"), Starttime("10.00.00"),
+		RecordingIdentification(RecordingID{Startdate: time.Date(2084, time.February, 1, 0, 0, 0, 0, time.UTC)}))
+	if err != nil {
+		t.Error("For TestStartTimeRecordingYear\n", err)
+		return
+	}
+	next := time.Date(2090, time.March, 4, 5, 6, 7, 0, time.UTC)
+	if err = h.setStartTime(next); err != nil {
+		t.Error("For TestStartTimeRecordingYear\n", err)
+		return
+	}
+	if !h.StartTime().Equal(next) || h.LocalRecordID() != "Startdate 04-MAR-2090 X X X" ||
+		string(h.startdate[:]) != "04.03.yy" {
+		t.Error("For TestStartTimeRecordingYear\n",
+			"Expected: ", next, "Startdate 04-MAR-2090 X X X", "04.03.yy",
+			"Got: ", h.StartTime(), h.LocalRecordID(), string(h.startdate[:]))
+	}
+
+	// A startdate of yy takes its year from the Startdate subfield
+	for _, tc := range []struct {
+		lrid     RecordingID
+		expected time.Time
+	}{
+		{RecordingID{Startdate: time.Date(2090, time.February, 1, 0, 0, 0, 0, time.UTC)},
+			time.Date(2090, time.February, 1, 10, 0, 0, 0, time.UTC)},
+		{RecordingID{}, time.Time{}},
+		{RecordingID{Startdate: time.Date(2090, time.March, 1, 0, 0, 0, 0, time.UTC)}, time.Time{}},
+	} {
+		h, err := NewHeader(NumSignal("1"), Startdate("01.02.yy"), Starttime("10.00.00"),
+			RecordingIdentification(tc.lrid))
+		if err != nil {
+			t.Error("For TestStartTimeRecordingYear\n", err)
+			return
+		}
+		if !h.StartTime().Equal(tc.expected) {
+			t.Error("For TestStartTimeRecordingYear\n",
+				"Expected: ", tc.expected,
+				"Got: ", h.StartTime())
+		}
+		var found bool
+		for _, e := range h.Validate() {
+			if e.Field == "startdate" {
+				found = true
+			}
+		}
+		if found != tc.expected.IsZero() {
+			t.Error("For TestStartTimeRecordingYear\n",
+				"Expected startdate error: ", tc.expected.IsZero(),
+				"Got: ", found)
+		}
+	}
+}
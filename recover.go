@@ -0,0 +1,99 @@
+package biosigio
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// recovery holds the complete raw data records found in a damaged file
+type recovery struct {
+	header    *Header
+	numsample []int
+	records   [][]byte
+	warnings  []error
+}
+
+// recoverRecords splits buf into as many complete data records as it holds,
+// reporting a partial trailing data record or extra bytes as warnings
+func recoverRecords(buf []byte, byteSize int) (r *recovery, err error) {
+	r = &recovery{}
+	r.header, buf, err = unmarshalHeader(buf)
+	if err != nil {
+		return nil, err
+	}
+	r.numsample, err = r.header.sampleCounts()
+	if err != nil {
+		return nil, err
+	}
+	size, err := r.header.recordSize(byteSize)
+	if err != nil {
+		return nil, err
+	}
+	numdatar, err := asciiToInt(r.header.numdatar[:])
+	if err != nil {
+		r.warnings = append(r.warnings, fmt.Errorf("number of data records %q does not parse", r.header.numdatar))
+		numdatar = UnknownNumDataRecord
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("data record size [%v] must be positive", size)
+	}
+
+	available := len(buf) / size
+	switch {
+	case numdatar < 0:
+		numdatar = available
+	case numdatar > available:
+		r.warnings = append(r.warnings, fmt.Errorf("header declares %v data records, %v are complete",
+			numdatar, available))
+		numdatar = available
+	}
+	for idx := 0; idx < numdatar; idx++ {
+		r.records = append(r.records, buf[:size])
+		buf = buf[size:]
+	}
+	switch {
+	case len(buf) == 0:
+	case len(buf) < size:
+		r.warnings = append(r.warnings, fmt.Errorf("partial data record of %v bytes out of %v dropped",
+			len(buf), size))
+	default:
+		r.warnings = append(r.warnings, fmt.Errorf("%v bytes after %v data records dropped",
+			len(buf), numdatar))
+	}
+	return r, nil
+}
+
+// RecoverFile unmarshals every complete data record of a truncated or padded
+// file. A partial trailing data record, extra bytes and a number of data
+// records disagreeing with the header are returned as warnings rather than
+// errors. Call FixNumDataRecord to make the header match what was recovered.
+func RecoverFile[T Sample](buf []byte) (f *File[T], warnings []error, err error) {
+	r, err := recoverRecords(buf, sampleSize[T]())
+	if err != nil {
+		return nil, nil, err
+	}
+	d := make([]*Record[T], len(r.records))
+	for idx, raw := range r.records {
+		d[idx] = &Record[T]{}
+		if err = d[idx].unmarshalSignals(raw, r.numsample); err != nil {
+			return nil, r.warnings, err
+		}
+	}
+	return NewFile(r.header, d), r.warnings, nil
+}
+
+// RecoverEDF unmarshals every complete data record of a damaged EDF file
+func RecoverEDF(buf []byte) (edf *EDF, warnings []error, err error) {
+	return RecoverFile[int16](buf)
+}
+
+// RecoverBDF unmarshals every complete data record of a damaged BDF file
+func RecoverBDF(buf []byte) (bdf *BDF, warnings []error, err error) {
+	return RecoverFile[int32](buf)
+}
+
+// FixNumDataRecord sets the number of data records in the header to the
+// number of data records held
+func (f *File[T]) FixNumDataRecord() error {
+	return f.Header.setNumDataRecord(strconv.Itoa(len(f.DataRecords)))
+}
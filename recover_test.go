@@ -0,0 +1,67 @@
+package biosigio
+
+import (
+	"testing"
+)
+
+func TestRecoverEDF(t *testing.T) {
+	edf, err := validEDF()
+	if err != nil {
+		t.Error("For TestRecoverEDF\n", err)
+		return
+	}
+	edf.DataRecords = append(edf.DataRecords, &EDFData{Signals: [][]int16{{6, 7}, {8, 9, 10}}})
+	edf.FixNumDataRecord()
+	buf, err := MarshalEDF(edf)
+	if err != nil {
+		t.Error("For TestRecoverEDF\n", err)
+		return
+	}
+
+	for _, tc := range []struct {
+		buf      []byte
+		records  int
+		warnings int
+	}{
+		{buf, 2, 0},
+		{buf[:len(buf)-3], 1, 2},
+		{append(append([]byte(nil), buf...), 0, 0, 0), 2, 1},
+	} {
+		recovered, warnings, err := RecoverEDF(tc.buf)
+		if err != nil {
+			t.Error("For TestRecoverEDF\n", err)
+			continue
+		}
+		if len(recovered.DataRecords) != tc.records || len(warnings) != tc.warnings {
+			t.Error("For TestRecoverEDF\n",
+				"Expected: ", tc.records, " records ", tc.warnings, " warnings",
+				"Got: ", len(recovered.DataRecords), warnings)
+		}
+		if err = recovered.FixNumDataRecord(); err != nil {
+			t.Error("For TestRecoverEDF\n", err)
+		}
+		if errs := recovered.Validate(); len(errs) != 0 {
+			t.Error("For TestRecoverEDF\n",
+				"Expected: ", "valid recovered file",
+				"Got: ", errs)
+		}
+	}
+	if _, _, err = RecoverEDF(buf[:FixedHeaderBytes]); err == nil {
+		t.Error("For TestRecoverEDF\n", "Expected error for missing variable header")
+	}
+	for _, numsample := range []string{"-2", "0"} {
+		h, err := NewHeader(NumDataRecord("1"), NumSignal("1"), NumSamples([]string{numsample}))
+		if err != nil {
+			t.Error("For TestRecoverEDF\n", err)
+			return
+		}
+		buf, err := MarshalEDF(NewEDF(h, nil))
+		if err != nil {
+			t.Error("For TestRecoverEDF\n", err)
+			return
+		}
+		if _, _, err = RecoverEDF(append(buf, make([]byte, 4)...)); err == nil {
+			t.Error("For TestRecoverEDF\n", "Expected error for ", numsample, " samples per data record")
+		}
+	}
+}
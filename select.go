@@ -0,0 +1,64 @@
+package biosigio
+
+import "fmt"
+
+// SelectSignals returns a new file holding copies of the signals idxs, in
+// that order. The per-signal header fields, numsignal and numbytes are
+// rewritten to match. An EDF+ or BDF+ file must keep an annotation signal.
+func (f *File[T]) SelectSignals(idxs ...int) (*File[T], error) {
+	if len(idxs) == 0 {
+		return nil, fmt.Errorf("no signals selected")
+	}
+	old := f.Header.signalHeaders()
+	sigs := make([]signalHeader, len(idxs))
+	seen := make(map[int]bool)
+	for ids, idx := range idxs {
+		if idx < 0 || idx >= len(old) {
+			return nil, fmt.Errorf("signal %v out of range [0, %v)", idx, len(old))
+		}
+		if seen[idx] {
+			return nil, fmt.Errorf("signal %v selected more than once", idx)
+		}
+		seen[idx] = true
+		sigs[ids] = old[idx]
+	}
+	h, err := f.Header.withSignals(sigs)
+	if err != nil {
+		return nil, err
+	}
+	if f.Header.EDFPlus() && len(h.AnnotationSignals()) == 0 {
+		return nil, fmt.Errorf("EDF+ or BDF+ file must keep an annotation signal")
+	}
+	records := make([]*Record[T], len(f.DataRecords))
+	for idr, record := range f.DataRecords {
+		signals := make([][]T, len(idxs))
+		for ids, idx := range idxs {
+			if idx >= len(record.Signals) {
+				return nil, fmt.Errorf("data record %v holds %v signals, want signal %v",
+					idr, len(record.Signals), idx)
+			}
+			signals[ids] = append([]T(nil), record.Signals[idx]...)
+		}
+		records[idr] = &Record[T]{Signals: signals}
+	}
+	return NewFile(h, records), nil
+}
+
+// SelectSignalsByLabel returns a new file holding copies of the first signal
+// with each of labels, in that order
+func (f *File[T]) SelectSignalsByLabel(labels ...string) (*File[T], error) {
+	idxs := make([]int, len(labels))
+	for ids, label := range labels {
+		idxs[ids] = -1
+		for idx, val := range f.Header.label {
+			if trimField(val[:]) == label {
+				idxs[ids] = idx
+				break
+			}
+		}
+		if idxs[ids] < 0 {
+			return nil, fmt.Errorf("no signal labelled %q", label)
+		}
+	}
+	return f.SelectSignals(idxs...)
+}
@@ -0,0 +1,70 @@
+package biosigio
+
+import "testing"
+
+func TestSelectSignals(t *testing.T) {
+	h, err := NewHeader(Version("0"), NumDataRecord("2"), Duration("1"),
+		NumSignal("3"), Labels([]string{"EEG Fpz-Cz", "ECG", "EMG"}),
+		PhysicalDimensions([]string{"uV", "mV", "uV"}),
+		NumSamples([]string{"4", "2", "1"}))
+	if err != nil {
+		t.Error("For TestSelectSignals\n", err)
+		return
+	}
+	edf := NewEDF(h, []*EDFData{
+		{Signals: [][]int16{{1, 2, 3, 4}, {5, 6}, {7}}},
+		{Signals: [][]int16{{8, 9, 10, 11}, {12, 13}, {14}}},
+	})
+	sel, err := edf.SelectSignalsByLabel("EMG", "EEG Fpz-Cz")
+	if err != nil {
+		t.Error("For TestSelectSignals\n", err)
+		return
+	}
+	for _, e := range sel.Validate() {
+		if e.Field == "numsignal" || e.Field == "numbytes" || e.Field == "DataRecords" {
+			t.Error("For TestSelectSignals\n", e)
+		}
+	}
+	buf, err := MarshalEDF(sel)
+	if err != nil {
+		t.Error("For TestSelectSignals\n", err)
+		return
+	}
+	newEDF, err := UnmarshalEDF(buf)
+	if err != nil {
+		t.Error("For TestSelectSignals\n", err)
+		return
+	}
+	labels := newEDF.Header.Labels()
+	if len(labels) != 2 || labels[0] != "EMG" || labels[1] != "EEG Fpz-Cz" ||
+		newEDF.Header.NumSignals() != 2 || newEDF.Header.NumBytes() != 3*256 {
+		t.Error("For TestSelectSignals\n",
+			"Expected: ", []string{"EMG", "EEG Fpz-Cz"}, 2, 3*256,
+			"Got: ", labels, newEDF.Header.NumSignals(), newEDF.Header.NumBytes())
+	}
+	if dims := newEDF.Header.PhysicalDimensions(); dims[0] != "uV" || newEDF.Header.NumSamples()[0] != 1 {
+		t.Error("For TestSelectSignals\n",
+			"Expected: ", "uV", 1,
+			"Got: ", dims[0], newEDF.Header.NumSamples()[0])
+	}
+	if newEDF.DataRecords[1].Signals[0][0] != 14 || newEDF.DataRecords[1].Signals[1][3] != 11 {
+		t.Error("For TestSelectSignals\n",
+			"Expected: ", [][]int16{{14}, {8, 9, 10, 11}},
+			"Got: ", newEDF.DataRecords[1].Signals)
+	}
+	// The original file is left untouched
+	sel.DataRecords[0].Signals[0][0] = 100
+	if edf.DataRecords[0].Signals[2][0] != 7 || edf.Header.NumSignals() != 3 {
+		t.Error("For TestSelectSignals\n",
+			"Expected: ", 7, 3,
+			"Got: ", edf.DataRecords[0].Signals[2][0], edf.Header.NumSignals())
+	}
+	for _, idxs := range [][]int{{}, {3}, {0, 0}} {
+		if _, err = edf.SelectSignals(idxs...); err == nil {
+			t.Error("For TestSelectSignals\n", "Expected error for ", idxs)
+		}
+	}
+	if _, err = edf.SelectSignalsByLabel("Resp"); err == nil {
+		t.Error("For TestSelectSignals\n", "Expected error for missing label")
+	}
+}
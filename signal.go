@@ -0,0 +1,177 @@
+package biosigio
+
+import (
+	"fmt"
+	"time"
+)
+
+// Signal is a view of one signal across all data records of a file
+type Signal[T Sample] struct {
+	Index     int
+	Label     string
+	file      *File[T]
+	numsample int
+	duration  time.Duration
+}
+
+// EDFSignal is a view of one signal across all data records of an EDF file
+type EDFSignal = Signal[int16]
+
+// BDFSignal is a view of one signal across all data records of a BDF file
+type BDFSignal = Signal[int32]
+
+// Signal returns a view of signal idx
+func (f *File[T]) Signal(idx int) (*Signal[T], error) {
+	if idx < 0 || idx >= len(f.Header.label) {
+		return nil, fmt.Errorf("signal %v out of range [0, %v)", idx, len(f.Header.label))
+	}
+	numsample, err := asciiToInt(f.Header.numsample[idx][:])
+	if err != nil {
+		return nil, fmt.Errorf("serialize ascii to int: %v, for %v", err, f.Header.numsample[idx])
+	}
+	duration, err := asciiToDuration(f.Header.duration[:])
+	if err != nil {
+		return nil, fmt.Errorf("serialize ascii to duration: %v, for %v", err, f.Header.duration)
+	}
+	return &Signal[T]{
+		Index:     idx,
+		Label:     trimField(f.Header.label[idx][:]),
+		file:      f,
+		numsample: numsample,
+		duration:  duration,
+	}, nil
+}
+
+// SignalByLabel returns a view of the first signal labelled label
+func (f *File[T]) SignalByLabel(label string) (*Signal[T], error) {
+	for idx, val := range f.Header.label {
+		if trimField(val[:]) == label {
+			return f.Signal(idx)
+		}
+	}
+	return nil, fmt.Errorf("no signal labelled %q", label)
+}
+
+// Len returns the number of samples of the signal in all data records
+func (s *Signal[T]) Len() int {
+	return s.numsample * len(s.file.DataRecords)
+}
+
+// SampleRate returns the number of samples per second, zero when data records
+// have no duration
+func (s *Signal[T]) SampleRate() float64 {
+	return s.file.Header.SampleRate(s.Index)
+}
+
+// Records returns the samples of the signal in each data record. The slices
+// are shared with the data records and not copied.
+func (s *Signal[T]) Records() [][]T {
+	records := make([][]T, len(s.file.DataRecords))
+	for idr, record := range s.file.DataRecords {
+		records[idr] = record.Signals[s.Index]
+	}
+	return records
+}
+
+// Digital returns the samples of the signal in all data records in order. The
+// samples of a file holding a single data record are shared, not copied.
+func (s *Signal[T]) Digital() []T {
+	if len(s.file.DataRecords) == 1 {
+		return s.file.DataRecords[0].Signals[s.Index]
+	}
+	samples := make([]T, 0, s.Len())
+	for _, record := range s.file.DataRecords {
+		samples = append(samples, record.Signals[s.Index]...)
+	}
+	return samples
+}
+
+// Physical returns the samples of the signal in all data records converted to
+// physical units
+func (s *Signal[T]) Physical() ([]float64, error) {
+	if isAnnotationLabel(s.Label) {
+		return nil, fmt.Errorf("signal %v holds annotations", s.Index)
+	}
+	sc, err := s.file.Header.signalScale(s.Index)
+	if err != nil {
+		return nil, err
+	}
+	physical := make([]float64, 0, s.Len())
+	for _, record := range s.file.DataRecords {
+		for _, val := range record.Signals[s.Index] {
+			physical = append(physical, sc.physical(int(val)))
+		}
+	}
+	return physical, nil
+}
+
+// Times returns the time of each sample relative to the start of the
+// recording, following the data record starts of EDF+D files
+func (s *Signal[T]) Times() ([]time.Duration, error) {
+	times := make([]time.Duration, 0, s.Len())
+	for idr := range s.file.DataRecords {
+		start, err := s.file.RecordStart(idr)
+		if err != nil {
+			return nil, err
+		}
+		for idy := 0; idy < s.numsample; idy++ {
+			times = append(times, start+s.duration*time.Duration(idy)/time.Duration(s.numsample))
+		}
+	}
+	return times, nil
+}
+
+// window calls fn with the data record and sample index of each sample timed
+// in [start, end)
+func (s *Signal[T]) window(start, end time.Duration, fn func(idr, idy int, t time.Duration)) error {
+	for idr := range s.file.DataRecords {
+		rstart, err := s.file.RecordStart(idr)
+		if err != nil {
+			return err
+		}
+		if rstart >= end || rstart+s.duration <= start {
+			continue
+		}
+		for idy := 0; idy < s.numsample; idy++ {
+			t := rstart + s.duration*time.Duration(idy)/time.Duration(s.numsample)
+			if t >= start && t < end {
+				fn(idr, idy, t)
+			}
+		}
+	}
+	return nil
+}
+
+// Window returns the samples of the signal timed in [start, end), measured
+// from the start of the recording, and the time of each. Signals of different
+// sample rates cover the same span of time for the same window.
+func (s *Signal[T]) Window(start, end time.Duration) (samples []T, times []time.Duration, err error) {
+	err = s.window(start, end, func(idr, idy int, t time.Duration) {
+		samples = append(samples, s.file.DataRecords[idr].Signals[s.Index][idy])
+		times = append(times, t)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return samples, times, nil
+}
+
+// PhysicalWindow returns the samples of the signal timed in [start, end)
+// converted to physical units, and the time of each
+func (s *Signal[T]) PhysicalWindow(start, end time.Duration) (physical []float64, times []time.Duration, err error) {
+	if isAnnotationLabel(s.Label) {
+		return nil, nil, fmt.Errorf("signal %v holds annotations", s.Index)
+	}
+	sc, err := s.file.Header.signalScale(s.Index)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = s.window(start, end, func(idr, idy int, t time.Duration) {
+		physical = append(physical, sc.physical(int(s.file.DataRecords[idr].Signals[s.Index][idy])))
+		times = append(times, t)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return physical, times, nil
+}
@@ -0,0 +1,144 @@
+package biosigio
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSignal(t *testing.T) {
+	h, err := NewHeader(Version("0"), NumDataRecord("2"), Duration("0.5"),
+		NumSignal("2"), Labels([]string{"EEG Fpz-Cz", "Resp"}),
+		PhysicalMins([]string{"-100", "0"}), PhysicalMaxs([]string{"100", "10"}),
+		DigitalMins([]string{"-100", "0"}), DigitalMaxs([]string{"100", "10"}),
+		NumSamples([]string{"4", "1"}))
+	if err != nil {
+		t.Error("For TestSignal\n", err)
+		return
+	}
+	edf := NewEDF(h, []*EDFData{
+		{Signals: [][]int16{{1, 2, 3, 4}, {7}}},
+		{Signals: [][]int16{{5, 6, 7, 8}, {9}}},
+	})
+	sig, err := edf.SignalByLabel("EEG Fpz-Cz")
+	if err != nil {
+		t.Error("For TestSignal\n", err)
+		return
+	}
+	if sig.Index != 0 || sig.Len() != 8 || sig.SampleRate() != 8 {
+		t.Error("For TestSignal\n",
+			"Expected: ", 0, 8, 8.0,
+			"Got: ", sig.Index, sig.Len(), sig.SampleRate())
+	}
+	digital := sig.Digital()
+	for idx, val := range digital {
+		if val != int16(idx+1) {
+			t.Error("For TestSignal\n",
+				"Expected: ", []int16{1, 2, 3, 4, 5, 6, 7, 8},
+				"Got: ", digital)
+			break
+		}
+	}
+	// Records share the samples of the data records
+	sig.Records()[1][0] = 50
+	if edf.DataRecords[1].Signals[0][0] != 50 {
+		t.Error("For TestSignal\n",
+			"Expected: ", 50,
+			"Got: ", edf.DataRecords[1].Signals[0][0])
+	}
+	resp, err := edf.Signal(1)
+	if err != nil {
+		t.Error("For TestSignal\n", err)
+		return
+	}
+	physical, err := resp.Physical()
+	if err != nil {
+		t.Error("For TestSignal\n", err)
+		return
+	}
+	if len(physical) != 2 || math.Abs(physical[0]-7) > 1e-9 || math.Abs(physical[1]-9) > 1e-9 {
+		t.Error("For TestSignal\n",
+			"Expected: ", []float64{7, 9},
+			"Got: ", physical)
+	}
+	times, err := sig.Times()
+	if err != nil {
+		t.Error("For TestSignal\n", err)
+		return
+	}
+	for idx, val := range times {
+		if val != time.Duration(idx)*125*time.Millisecond {
+			t.Error("For TestSignal\n",
+				"Expected: ", time.Duration(idx)*125*time.Millisecond,
+				"Got: ", val)
+		}
+	}
+	if _, err = edf.SignalByLabel("ECG"); err == nil {
+		t.Error("For TestSignal\n", "Expected error for missing label")
+	}
+	if _, err = edf.Signal(2); err == nil {
+		t.Error("For TestSignal\n", "Expected error for signal out of range")
+	}
+}
+
+func TestSignalWindow(t *testing.T) {
+	h, err := NewHeader(Version("0"), NumDataRecord("3"), Duration("2"),
+		NumSignal("2"), Labels([]string{"EEG Fpz-Cz", "SpO2"}),
+		NumSamples([]string{"8", "2"}))
+	if err != nil {
+		t.Error("For TestSignalWindow\n", err)
+		return
+	}
+	records := make([]*EDFData, 3)
+	for idr := range records {
+		eeg := make([]int16, 8)
+		for idy := range eeg {
+			eeg[idy] = int16(idr*8 + idy)
+		}
+		records[idr] = &EDFData{Signals: [][]int16{eeg, {int16(idr * 2), int16(idr*2 + 1)}}}
+	}
+	edf := NewEDF(h, records)
+	if h.SampleRate(0) != 4 || h.SampleRate(1) != 1 || h.SampleRate(2) != 0 {
+		t.Error("For TestSignalWindow\n",
+			"Expected: ", 4.0, 1.0, 0.0,
+			"Got: ", h.SampleRate(0), h.SampleRate(1), h.SampleRate(2))
+	}
+	// The same window spans 1.5s to 4s of both signals, across data records
+	for _, tc := range []struct {
+		label    string
+		expected []int16
+	}{
+		{"EEG Fpz-Cz", []int16{6, 7, 8, 9, 10, 11, 12, 13, 14, 15}},
+		{"SpO2", []int16{2, 3}},
+	} {
+		sig, err := edf.SignalByLabel(tc.label)
+		if err != nil {
+			t.Error("For TestSignalWindow\n", err)
+			return
+		}
+		samples, times, err := sig.Window(1500*time.Millisecond, 4*time.Second)
+		if err != nil {
+			t.Error("For TestSignalWindow\n", err)
+			return
+		}
+		if len(samples) != len(tc.expected) || len(times) != len(samples) {
+			t.Error("For TestSignalWindow\n",
+				"Expected: ", tc.expected,
+				"Got: ", samples, times)
+			continue
+		}
+		for idx, val := range samples {
+			if val != tc.expected[idx] {
+				t.Error("For TestSignalWindow\n",
+					"Expected: ", tc.expected,
+					"Got: ", samples)
+				break
+			}
+		}
+		if times[0] < 1500*time.Millisecond || times[len(times)-1] >= 4*time.Second {
+			t.Error("For TestSignalWindow\n",
+				"Expected: ", "times in [1.5s, 4s)",
+				"Got: ", times)
+		}
+	}
+}
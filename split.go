@@ -0,0 +1,69 @@
+package biosigio
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+)
+
+// Split divides the data records into new files, each covering `every` of
+// the recording, measured from the start of the first data record. Data
+// records are kept whole in the file their start falls into, so every should
+// be a multiple of the data record duration. Each file has its own startdate,
+// starttime and numdatar, and EDF+ annotations are carried over as in Crop.
+// Splitting a plain EDF file fails when a file would start past a second.
+func (f *File[T]) Split(every time.Duration) (files []*File[T], err error) {
+	if every <= 0 {
+		return nil, fmt.Errorf("split length %v must be positive", every)
+	}
+	if len(f.DataRecords) == 0 {
+		return nil, fmt.Errorf("no data records to split")
+	}
+	var idxs []int
+	var starts []time.Duration
+	var origin time.Duration
+	chunk := 0
+	for idr := range f.DataRecords {
+		rstart, err := f.RecordStart(idr)
+		if err != nil {
+			return nil, err
+		}
+		if idr == 0 {
+			origin = rstart
+		}
+		if n := int((rstart - origin) / every); n != chunk && len(idxs) > 0 {
+			c, err := f.extract(idxs, starts)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, c)
+			idxs, starts, chunk = nil, nil, n
+		}
+		idxs = append(idxs, idr)
+		starts = append(starts, rstart)
+	}
+	c, err := f.extract(idxs, starts)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, c), nil
+}
+
+// WriteSplit splits the file as Split does and writes each part to the file
+// named by name, called with the index and contents of the part
+func (f *File[T]) WriteSplit(every time.Duration, name func(idx int, part *File[T]) string) error {
+	files, err := f.Split(every)
+	if err != nil {
+		return err
+	}
+	for idx, part := range files {
+		buf, err := MarshalFile(part)
+		if err != nil {
+			return fmt.Errorf("part %v: %v", idx, err)
+		}
+		if err = ioutil.WriteFile(name(idx, part), buf, 0644); err != nil {
+			return fmt.Errorf("part %v: %v", idx, err)
+		}
+	}
+	return nil
+}
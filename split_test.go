@@ -0,0 +1,108 @@
+package biosigio
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSplit(t *testing.T) {
+	edf, err := plusEDF(5, []Annotation{{Onset: 1200 * time.Millisecond, Text: "Arousal"}})
+	if err != nil {
+		t.Error("For TestSplit\n", err)
+		return
+	}
+	files, err := edf.Split(time.Second)
+	if err != nil {
+		t.Error("For TestSplit\n", err)
+		return
+	}
+	if len(files) != 3 {
+		t.Error("For TestSplit\n",
+			"Expected: ", 3,
+			"Got: ", len(files))
+		return
+	}
+	start := time.Date(2015, time.July, 12, 21, 18, 32, 0, time.UTC)
+	for idx, part := range files {
+		n := 2
+		if idx == 2 {
+			n = 1
+		}
+		if part.Header.NumDataRecords() != n || len(part.DataRecords) != n ||
+			part.DataRecords[0].Signals[0][0] != int16(idx*2) {
+			t.Error("For TestSplit\n",
+				"Expected: ", n, idx*2,
+				"Got: ", part.Header.NumDataRecords(), part.DataRecords[0].Signals[0])
+		}
+		expected := start.Add(time.Duration(idx) * time.Second)
+		if !part.Header.StartTime().Equal(expected) {
+			t.Error("For TestSplit\n",
+				"Expected: ", expected,
+				"Got: ", part.Header.StartTime())
+		}
+	}
+	anns, err := files[1].Annotations()
+	if err != nil || len(anns) != 1 || anns[0].Onset != 200*time.Millisecond {
+		t.Error("For TestSplit\n",
+			"Expected: ", []Annotation{{Onset: 200 * time.Millisecond, Text: "Arousal"}},
+			"Got: ", anns, err)
+	}
+
+	dir, err := ioutil.TempDir("", "biosigio")
+	if err != nil {
+		t.Error("For TestSplit\n", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	err = edf.WriteSplit(2*time.Second, func(idx int, part *EDF) string {
+		return filepath.Join(dir, fmt.Sprintf("part%v.edf", idx))
+	})
+	if err != nil {
+		t.Error("For TestSplit\n", err)
+		return
+	}
+	rec, err := Open(filepath.Join(dir, "part1.edf"))
+	if err != nil {
+		t.Error("For TestSplit\n", err)
+		return
+	}
+	if part, ok := rec.(*EDF); !ok || len(part.DataRecords) != 1 || part.DataRecords[0].Signals[0][0] != 4 {
+		t.Error("For TestSplit\n",
+			"Expected: ", "one data record of 4s",
+			"Got: ", rec)
+	}
+	// Parts starting past a second keep the fraction in their time-keeping
+	// annotations
+	files, err = edf.Split(1500 * time.Millisecond)
+	if err != nil {
+		t.Error("For TestSplit\n", err)
+		return
+	}
+	if len(files) != 2 || len(files[0].DataRecords) != 3 || len(files[1].DataRecords) != 2 {
+		t.Error("For TestSplit\n",
+			"Expected: ", 2, 3, 2,
+			"Got: ", len(files))
+		return
+	}
+	rstart, err := files[1].RecordStart(0)
+	if err != nil || rstart != 500*time.Millisecond || !files[1].Header.StartTime().Equal(start.Add(time.Second)) {
+		t.Error("For TestSplit\n",
+			"Expected: ", 500*time.Millisecond, start.Add(time.Second),
+			"Got: ", rstart, files[1].Header.StartTime(), err)
+	}
+	plain, err := plainEDF(5)
+	if err != nil {
+		t.Error("For TestSplit\n", err)
+		return
+	}
+	if _, err = plain.Split(1500 * time.Millisecond); err == nil {
+		t.Error("For TestSplit\n", "Expected error for plain EDF part starting at 1.5s")
+	}
+	if _, err = edf.Split(0); err == nil {
+		t.Error("For TestSplit\n", "Expected error for zero split length")
+	}
+}
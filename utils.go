@@ -3,8 +3,10 @@ package biosigio
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kevinjos/eeg-web-server/int24"
 )
@@ -15,6 +17,11 @@ func fillWithSpaces(input []byte) {
 	}
 }
 
+// trimField returns a header field without its padding
+func trimField(field []byte) string {
+	return strings.TrimRight(string(field), "\x00\x20")
+}
+
 func asciiToInt(ascii []byte) (n int, err error) {
 	sArr := make([]string, len(ascii))
 	for idx, val := range ascii {
@@ -32,6 +39,85 @@ func asciiToInt(ascii []byte) (n int, err error) {
 	return n, nil
 }
 
+func asciiToFloat(ascii []byte) (f float64, err error) {
+	s := strings.TrimRight(string(ascii), "\x00\x20")
+	f, err = strconv.ParseFloat(strings.TrimLeft(s, "\x20"), 64)
+	if err != nil {
+		return 0, err
+	}
+	return f, nil
+}
+
+// asciiToDuration parses a decimal number of seconds such as "1", "0.5" or
+// "+1234.5678" without loss of precision down to the nanosecond
+func asciiToDuration(ascii []byte) (d time.Duration, err error) {
+	s := strings.TrimRight(string(ascii), "\x00\x20")
+	var neg bool
+	switch {
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	case strings.HasPrefix(s, "-"):
+		neg = true
+		s = s[1:]
+	}
+	intPart, fracPart := s, ""
+	if idx := strings.IndexByte(s, '.'); idx >= 0 {
+		intPart, fracPart = s[:idx], s[idx+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return 0, fmt.Errorf("invalid number of seconds %q", ascii)
+	}
+	var sec, nsec int64
+	if intPart != "" {
+		if sec, err = strconv.ParseInt(intPart, 10, 64); err != nil || sec < 0 {
+			return 0, fmt.Errorf("invalid number of seconds %q", ascii)
+		}
+	}
+	if len(fracPart) > 9 {
+		fracPart = fracPart[:9]
+	}
+	if fracPart != "" {
+		fracPart += strings.Repeat("0", 9-len(fracPart))
+		if nsec, err = strconv.ParseInt(fracPart, 10, 64); err != nil || nsec < 0 {
+			return 0, fmt.Errorf("invalid number of seconds %q", ascii)
+		}
+	}
+	d = time.Duration(sec)*time.Second + time.Duration(nsec)
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// durationToASCII formats d as a signed decimal number of seconds
+func durationToASCII(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	s := sign + strconv.FormatInt(int64(d/time.Second), 10)
+	if frac := d % time.Second; frac != 0 {
+		s += "." + strings.TrimRight(fmt.Sprintf("%09d", int64(frac)), "0")
+	}
+	return s
+}
+
+// floatToASCII formats f in at most width characters, dropping decimal
+// places as needed
+func floatToASCII(f float64, width int) (string, error) {
+	for prec := width; prec >= 0; prec-- {
+		s := strconv.FormatFloat(f, 'f', prec, 64)
+		if strings.Contains(s, ".") {
+			s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		}
+		if len(s) <= width {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("%v does not fit in %v characters", f, width)
+}
+
 func fixedHeaderOffsets() map[string]int {
 	h, _ := NewHeader()
 	offset := make(map[string]int)
@@ -80,13 +166,35 @@ func toInt16(signal []byte) (res []int16, err error) {
 // to arrays of go int32
 func toInt32(signal []byte) (res []int32) {
 	res = make([]int32, len(signal)/3)
-	for idx, finished := 0, false; !finished; idx++ {
-		if (idx+1)*3 == len(signal) {
-			res[idx] = int24.UnmarshalSLE(signal[idx*3:])
-			finished = true
-		} else {
-			res[idx] = int24.UnmarshalSLE(signal[idx*3 : (idx+1)*3])
-		}
+	for idx := range res {
+		res[idx] = int24.UnmarshalSLE(signal[idx*3 : (idx+1)*3])
 	}
 	return res
 }
+
+// toSamples converts the bytes of one signal to samples of type T
+func toSamples[T Sample](signal []byte) (res []T, err error) {
+	var samples interface{}
+	switch any(res).(type) {
+	case []int16:
+		samples, err = toInt16(signal)
+	case []int32:
+		samples = toInt32(signal)
+	}
+	return samples.([]T), err
+}
+
+// appendSamples appends the 2-byte or 3-byte two's complement little-endian
+// encoding of samples of type T
+func appendSamples[T Sample](buf []byte, samples []T) []byte {
+	if sampleSize[T]() == EDFDataByteSize {
+		for _, val := range samples {
+			buf = append(buf, byte(val), byte(val>>8))
+		}
+		return buf
+	}
+	for _, val := range samples {
+		buf = append(buf, int24.MarshalSLE(int32(val))...)
+	}
+	return buf
+}
@@ -0,0 +1,204 @@
+package biosigio
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FieldError describes a header field or data record that breaks the EDF or
+// EDF+ specification. Field is the name of the header field, or DataRecords.
+// Index is the signal of a per-signal field or the data record, and -1
+// otherwise.
+type FieldError struct {
+	Field  string
+	Index  int
+	Value  string
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	if e.Index >= 0 {
+		return fmt.Sprintf("%s[%v] %q: %s", e.Field, e.Index, e.Value, e.Reason)
+	}
+	return fmt.Sprintf("%s %q: %s", e.Field, e.Value, e.Reason)
+}
+
+// Sample ranges of the 2-byte EDF and 3-byte BDF integers
+const (
+	edfSampleMin = -1 << 15
+	edfSampleMax = 1<<15 - 1
+	bdfSampleMin = -1 << 23
+	bdfSampleMax = 1<<23 - 1
+)
+
+// validator collects the field errors of one header
+type validator struct {
+	errs []*FieldError
+}
+
+func (v *validator) add(field string, idx int, value []byte, format string, args ...interface{}) {
+	v.errs = append(v.errs, &FieldError{
+		Field:  field,
+		Index:  idx,
+		Value:  string(value),
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+// printable checks a field holds only printable ascii
+func (v *validator) printable(field string, idx int, value []byte) {
+	for _, c := range value {
+		if c < 32 || c > 126 {
+			v.add(field, idx, value, "%s for %v", errNotPrintable, c)
+			return
+		}
+	}
+}
+
+// integer parses a field holding an integer of at least min
+func (v *validator) integer(field string, idx int, value []byte, min int) (n int, ok bool) {
+	n, err := asciiToInt(value)
+	if err != nil {
+		v.add(field, idx, value, "not an integer")
+		return 0, false
+	}
+	if n < min {
+		v.add(field, idx, value, "must be at least %v", min)
+		return n, false
+	}
+	return n, true
+}
+
+// number parses a field holding a decimal number
+func (v *validator) number(field string, idx int, value []byte) (f float64, ok bool) {
+	f, err := asciiToFloat(value)
+	if err != nil {
+		v.add(field, idx, value, "not a number")
+		return 0, false
+	}
+	return f, true
+}
+
+// Validate checks every header field against the EDF and EDF+ specification
+// and returns an error for each violation. The identification fields of
+// EDF+ files must hold the EDF+ subfields.
+func (h *Header) Validate() []*FieldError {
+	v := &validator{}
+	if h.version != EDFVersion && h.version != BDFVersion {
+		v.add("version", -1, h.version[:], "must be 0 for EDF or \\xFFBIOSEMI for BDF")
+	}
+	v.printable("LPID", -1, h.LPID[:])
+	v.printable("LRID", -1, h.LRID[:])
+	if h.EDFPlus() {
+		if _, err := ParsePatientID(trimField(h.LPID[:])); err != nil {
+			v.add("LPID", -1, h.LPID[:], "%v", err)
+		}
+		if _, err := ParseRecordingID(trimField(h.LRID[:])); err != nil {
+			v.add("LRID", -1, h.LRID[:], "%v", err)
+		}
+	}
+	if _, err := h.startDate(); err != nil {
+		v.add("startdate", -1, h.startdate[:], "must be dd.mm.yy: %v", err)
+	}
+	if _, err := parseStarttime(h.starttime[:]); err != nil {
+		v.add("starttime", -1, h.starttime[:], "must be hh.mm.ss: %v", err)
+	}
+	v.printable("reserved", -1, h.reserved[:])
+	v.integer("numdatar", -1, h.numdatar[:], UnknownNumDataRecord)
+	if d, err := asciiToDuration(h.duration[:]); err != nil || d < 0 {
+		v.add("duration", -1, h.duration[:], "must be a non-negative number of seconds")
+	}
+	ns, ok := v.integer("numsignal", -1, h.numsignal[:], 1)
+	if !ok {
+		return v.errs
+	}
+	if nb, ok := v.integer("numbytes", -1, h.numbytes[:], 0); ok && nb != FixedHeaderBytes+ns*VariableHeaderBytes {
+		v.add("numbytes", -1, h.numbytes[:], "must be %v for %v signals",
+			FixedHeaderBytes+ns*VariableHeaderBytes, ns)
+	}
+	for _, l := range []int{len(h.label), len(h.transducerType), len(h.phydim), len(h.phymin),
+		len(h.phymax), len(h.digmin), len(h.digmax), len(h.prefilter), len(h.numsample),
+		len(h.nsreserved)} {
+		if l != ns {
+			v.add("numsignal", -1, h.numsignal[:], "does not match %v per-signal fields", l)
+			return v.errs
+		}
+	}
+	if h.EDFPlus() && len(h.AnnotationSignals()) == 0 {
+		v.add("reserved", -1, h.reserved[:], "EDF+ and BDF+ require an %q or %q signal",
+			AnnotationsLabel, BDFAnnotationsLabel)
+	}
+	if h.EDFPlus() && plusReserved(h.dataByteSize(), h.Discontinuous()) != trimField(h.reserved[:5]) {
+		v.add("reserved", -1, h.reserved[:], "does not match version %q", h.version[:])
+	}
+
+	sampleMin, sampleMax := edfSampleMin, edfSampleMax
+	if h.dataByteSize() == BDFDataByteSize {
+		sampleMin, sampleMax = bdfSampleMin, bdfSampleMax
+	}
+	for idx := 0; idx < ns; idx++ {
+		v.printable("label", idx, h.label[idx][:])
+		v.printable("transducerType", idx, h.transducerType[idx][:])
+		v.printable("phydim", idx, h.phydim[idx][:])
+		v.printable("prefilter", idx, h.prefilter[idx][:])
+		v.printable("nsreserved", idx, h.nsreserved[idx][:])
+		phymin, okMin := v.number("phymin", idx, h.phymin[idx][:])
+		phymax, okMax := v.number("phymax", idx, h.phymax[idx][:])
+		if okMin && okMax && phymin == phymax {
+			v.add("phymax", idx, h.phymax[idx][:], "must differ from physical minimum")
+		}
+		digmin, okMin := v.integer("digmin", idx, h.digmin[idx][:], sampleMin)
+		digmax, okMax := v.integer("digmax", idx, h.digmax[idx][:], sampleMin)
+		if okMax && digmax > sampleMax {
+			v.add("digmax", idx, h.digmax[idx][:], "must be at most %v", sampleMax)
+		}
+		if okMin && okMax && digmin >= digmax {
+			v.add("digmax", idx, h.digmax[idx][:], "must be greater than digital minimum %v", digmin)
+		}
+		v.integer("numsample", idx, h.numsample[idx][:], 1)
+	}
+	return v.errs
+}
+
+// validateRecords checks the number of data records and the length of each
+// signal of each data record against the header
+func (h *Header) validateRecords(v *validator, lengths [][]int) {
+	if ndr, err := asciiToInt(h.numdatar[:]); err == nil && ndr != UnknownNumDataRecord && ndr != len(lengths) {
+		v.add("numdatar", -1, h.numdatar[:], "does not match %v data records", len(lengths))
+	}
+	numsample, err := h.sampleCounts()
+	if err != nil {
+		return
+	}
+	for idr, record := range lengths {
+		if len(record) != len(numsample) {
+			v.add("DataRecords", idr, []byte(strconv.Itoa(len(record))),
+				"number of signals must be %v", len(numsample))
+			continue
+		}
+		for idx, l := range record {
+			if l != numsample[idx] {
+				v.add("DataRecords", idr, []byte(strconv.Itoa(l)),
+					"signal %v must hold %v samples", idx, numsample[idx])
+			}
+		}
+	}
+}
+
+// Validate checks the header and the data records, including that the
+// version of the header matches the sample type
+func (f *File[T]) Validate() []*FieldError {
+	v := &validator{errs: f.Header.Validate()}
+	if f.Header.dataByteSize() != sampleSize[T]() {
+		v.add("version", -1, f.Header.version[:], "does not match %v-byte samples", sampleSize[T]())
+	}
+	lengths := make([][]int, len(f.DataRecords))
+	for idr, record := range f.DataRecords {
+		lengths[idr] = make([]int, len(record.Signals))
+		for idx, signal := range record.Signals {
+			lengths[idr][idx] = len(signal)
+		}
+	}
+	f.Header.validateRecords(v, lengths)
+	return v.errs
+}
@@ -0,0 +1,93 @@
+package biosigio
+
+import (
+	"testing"
+)
+
+func validEDF() (*EDF, error) {
+	h, err := NewHeader(Version("0"), LocalPatientID("X X X X"),
+		LocalRecordID("Startdate X X X X"), Startdate("12.07.15"), Starttime("21.18.32"),
+		NumDataRecord("1"), Duration("1"), NumSignal("2"), Labels([]string{"a", "b"}),
+		PhysicalMins([]string{"-500", "-500"}), PhysicalMaxs([]string{"500", "500"}),
+		DigitalMins([]string{"-2048", "-2048"}), DigitalMaxs([]string{"2047", "2047"}),
+		NumSamples([]string{"2", "3"}))
+	if err != nil {
+		return nil, err
+	}
+	return NewEDF(h, []*EDFData{{Signals: [][]int16{{1, 2}, {3, 4, 5}}}}), nil
+}
+
+func TestValidate(t *testing.T) {
+	edf, err := validEDF()
+	if err != nil {
+		t.Error("For TestValidate\n", err)
+		return
+	}
+	if errs := edf.Validate(); len(errs) != 0 {
+		t.Error("For TestValidate\n",
+			"Expected: ", "no errors",
+			"Got: ", errs)
+	}
+
+	for _, tc := range []struct {
+		field  string
+		index  int
+		mutate func(edf *EDF)
+	}{
+		{"startdate", -1, func(edf *EDF) { edf.Header.setStartdate("12/07/15") }},
+		{"starttime", -1, func(edf *EDF) { edf.Header.setStarttime("25.00.00") }},
+		{"numbytes", -1, func(edf *EDF) { edf.Header.setNumBytes("256") }},
+		{"numdatar", -1, func(edf *EDF) { edf.Header.setNumDataRecord("2") }},
+		{"duration", -1, func(edf *EDF) { edf.Header.setDuration("-1") }},
+		{"version", -1, func(edf *EDF) { edf.Header.version = BDFVersion }},
+		{"digmax", 1, func(edf *EDF) { edf.Header.digmax[1] = edf.Header.digmin[1] }},
+		{"digmin", 0, func(edf *EDF) { copy(edf.Header.digmin[0][:], "-99999  ") }},
+		{"phymax", 0, func(edf *EDF) { edf.Header.phymax[0] = edf.Header.phymin[0] }},
+		{"numsample", 1, func(edf *EDF) { copy(edf.Header.numsample[1][:], "x") }},
+		{"reserved", -1, func(edf *EDF) { edf.Header.setReserved(EDFPlusContinuous) }},
+		{"LPID", -1, func(edf *EDF) {
+			edf.Header.setReserved(EDFPlusContinuous)
+			edf.Header.setLPID("Jane Doe")
+		}},
+		{"LRID", -1, func(edf *EDF) {
+			edf.Header.setReserved(EDFPlusContinuous)
+			edf.Header.setLRID("Recorded 12-JUL-2015 X X X")
+		}},
+		{"DataRecords", 0, func(edf *EDF) { edf.DataRecords[0].Signals[1] = []int16{1} }},
+	} {
+		edf, _ := validEDF()
+		tc.mutate(edf)
+		errs := edf.Validate()
+		var found bool
+		for _, e := range errs {
+			if e.Field == tc.field && e.Index == tc.index {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("For TestValidate\n",
+				"Expected: ", tc.field, tc.index,
+				"Got: ", errs)
+		}
+	}
+}
+
+func TestUnmarshalEDFTruncated(t *testing.T) {
+	edf, err := validEDF()
+	if err != nil {
+		t.Error("For TestUnmarshalEDFTruncated\n", err)
+		return
+	}
+	buf, err := MarshalEDF(edf)
+	if err != nil {
+		t.Error("For TestUnmarshalEDFTruncated\n", err)
+		return
+	}
+	for _, n := range []int{100, FixedHeaderBytes + 10, len(buf) - 1} {
+		if _, err = UnmarshalEDF(buf[:n]); err == nil {
+			t.Error("For TestUnmarshalEDFTruncated\n",
+				"Expected: error for ", n, " bytes",
+				"Got: ", nil)
+		}
+	}
+}